@@ -1,8 +1,9 @@
 package client
 
 import (
-	"bufio"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -15,6 +16,17 @@ import (
 	"time"
 )
 
+const (
+	// uploadChunkSize is the size of the chunks of an uploaded image
+	uploadChunkSize = 32 << 10
+	// uploadAttempts is the number of streams an upload is sent by before it fails
+	uploadAttempts = 5
+	// uploadRetryDelay is the wait before the second stream of an upload, it grows with each attempt
+	uploadRetryDelay = 100 * time.Millisecond
+	// uploadStreamTimeout is the deadline of a stream of chunks
+	uploadStreamTimeout = time.Minute
+)
+
 // LaptopClient is a client to call laptop service rpc
 type LaptopClient struct {
 	service pb.LaptopServiceClient
@@ -88,76 +100,174 @@ func (laptopClient *LaptopClient) SearchLaptop(filter *pb.Filter) {
 	}
 }
 
-func (laptopClient *LaptopClient) UploadImage(laptopID string, imagePath string) {
-	// 1.prepare image data
+// UploadImage uploads the image file of a laptop and returns the image ID,
+// the upload resumes from the committed offset after a dropped stream
+func (laptopClient *LaptopClient) UploadImage(laptopID string, imagePath string) (string, error) {
 	file, err := os.Open(imagePath)
 	if err != nil {
-		log.Fatal("cannot open image file: ", err)
+		return "", fmt.Errorf("cannot open image file: %v", err)
 	}
 	defer file.Close()
 
-	// 2.timeout handle
+	stat, err := file.Stat()
+	if err != nil {
+		return "", fmt.Errorf("cannot stat image file: %v", err)
+	}
+	checksum := sha256.New()
+	_, err = io.Copy(checksum, file)
+	if err != nil {
+		return "", fmt.Errorf("cannot read image file: %v", err)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-
-	// 3.generate upload image stream
-	stream, err := laptopClient.service.UploadImage(ctx)
+	initRes, err := laptopClient.service.InitiateUpload(ctx, &pb.InitiateUploadRequest{
+		Info: &pb.ImageInfo{
+			LaptopId:  laptopID,
+			ImageType: filepath.Ext(imagePath),
+		},
+		Size: uint64(stat.Size()),
+	})
 	if err != nil {
-		log.Fatal("cannot upload image", err)
+		return "", fmt.Errorf("cannot initiate upload: %v", err)
 	}
+	uploadID := initRes.GetUploadId()
 
-	// 4. construct a pb.UploadImageRequest
-	req := &pb.UploadImageRequest{
-		Data: &pb.UploadImageRequest_Info{
-			Info: &pb.ImageInfo{
-				LaptopId:  laptopID,
-				ImageType: filepath.Ext(imagePath),
-			},
-		},
+	var offset int64
+	for attempt := 1; ; attempt++ {
+		offset, err = laptopClient.uploadChunks(uploadID, file, offset)
+		if err == nil {
+			if offset != stat.Size() {
+				return "", fmt.Errorf("upload stopped at offset %d of %d", offset, stat.Size())
+			}
+			break
+		}
+		if attempt >= uploadAttempts || !isResumable(err) {
+			return "", fmt.Errorf("cannot upload image: %v", err)
+		}
+		log.Printf("upload %s stopped at offset %d: %v, resuming", uploadID, offset, err)
+		time.Sleep(time.Duration(attempt) * uploadRetryDelay)
+
+		// the server knows which chunks were written before the stream was dropped
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		queryRes, err := laptopClient.service.QueryUpload(ctx, &pb.QueryUploadRequest{UploadId: uploadID})
+		cancel()
+		if err != nil {
+			return "", fmt.Errorf("cannot query upload: %v", err)
+		}
+		offset = int64(queryRes.GetCommittedOffset())
 	}
 
-	// 5. Send req by stream
-	err = stream.Send(req)
+	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	res, err := laptopClient.service.CommitUpload(ctx, &pb.CommitUploadRequest{
+		UploadId: uploadID,
+		Sha256:   hex.EncodeToString(checksum.Sum(nil)),
+	})
 	if err != nil {
-		log.Fatal("cannot send  image info", err)
+		return "", fmt.Errorf("cannot commit upload: %v", err)
 	}
+	log.Printf("Upload image %s succeed, size is %d", res.GetId(), res.GetSize())
+	return res.GetId(), nil
+}
 
-	// 6.stream method send image data
-	reader := bufio.NewReader(file)
-	buffer := make([]byte, 1024)
+// uploadChunks sends the chunks of the file from offset in a stream and returns the committed offset
+func (laptopClient *LaptopClient) uploadChunks(uploadID string, file io.ReaderAt, offset int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uploadStreamTimeout)
+	defer cancel()
+
+	stream, err := laptopClient.service.UploadChunks(ctx)
+	if err != nil {
+		return offset, err
+	}
+
+	buffer := make([]byte, uploadChunkSize)
 	for {
-		n, err := reader.Read(buffer)
+		n, err := file.ReadAt(buffer, offset)
+		if n > 0 {
+			req := &pb.UploadChunkRequest{
+				UploadId:  uploadID,
+				Offset:    uint64(offset),
+				ChunkData: buffer[:n],
+			}
+			sendErr := stream.Send(req)
+			if sendErr != nil {
+				// the server closed the stream, CloseAndRecv returns why
+				break
+			}
+			offset += int64(n)
+		}
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			log.Fatal("cannot read chunk to the buffer: ", err)
+			return offset, fmt.Errorf("cannot read image file: %v", err)
 		}
+	}
 
-		req := &pb.UploadImageRequest{
-			Data: &pb.UploadImageRequest_ChunkData{
-				ChunkData: buffer[:n], // attention buffer used
-			},
-		}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return offset, err
+	}
+	return int64(res.GetCommittedOffset()), nil
+}
+
+// isResumable returns true if an upload can continue after the error
+func isResumable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Aborted, codes.FailedPrecondition:
+		return true
+	default:
+		return false
+	}
+}
 
-		//log.Println(len(buffer[:n]))
+// DownloadImage writes the image with the given ID, or its thumbnail of the given size, to imagePath
+func (laptopClient *LaptopClient) DownloadImage(imageID string, size pb.ImageSize, imagePath string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-		err = stream.Send(req)
-		if err != nil {
-			err2 := stream.RecvMsg(nil)
-			log.Fatal("cannot send image file data: ", err, err2)
-		}
+	stream, err := laptopClient.service.DownloadImage(ctx, &pb.DownloadImageRequest{Id: imageID, Size: size})
+	if err != nil {
+		return fmt.Errorf("cannot download image: %v", err)
 	}
 
-	// 7. close and receive response, print response result
-	res, err := stream.CloseAndRecv()
+	// the first message is the image info
+	res, err := stream.Recv()
 	if err != nil {
-		log.Fatal("cannot receive response: ", err)
+		return fmt.Errorf("cannot receive image info: %v", err)
 	}
-	log.Printf("Upload image %s succeed, size is %d", res.GetId(), res.GetSize())
+	info := res.GetInfo()
+
+	file, err := os.Create(imagePath)
+	if err != nil {
+		return fmt.Errorf("cannot create image file: %v", err)
+	}
+	defer file.Close()
+
+	written := 0
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("cannot receive chunk data: %v", err)
+		}
+
+		n, err := file.Write(res.GetChunkData())
+		if err != nil {
+			return fmt.Errorf("cannot write image file: %v", err)
+		}
+		written += n
+	}
+
+	log.Printf("Download image %s of laptop %s succeed, size is %d", imageID, info.GetLaptopId(), written)
+	return nil
 }
 
-func (laptopClient *LaptopClient) RatingLaptop(laptopIDs []string, scores []float64) error {
+// RateLaptop sends the scores of the laptops, a new score of a laptop replaces the previous one of the user
+func (laptopClient *LaptopClient) RateLaptop(laptopIDs []string, scores []float64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -207,3 +317,24 @@ func (laptopClient *LaptopClient) RatingLaptop(laptopIDs []string, scores []floa
 
 	return err
 }
+
+// GetRatings logs the statistics of the scores of the laptops
+func (laptopClient *LaptopClient) GetRatings(laptopIDs []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := laptopClient.service.BatchGetRatings(ctx, &pb.BatchGetRatingsRequest{LaptopIds: laptopIDs})
+	if err != nil {
+		return fmt.Errorf("cannot get ratings: %v", err)
+	}
+
+	for _, rating := range res.GetRatings() {
+		log.Printf(
+			"rating of laptop %s: %d scores, average %.2f, min %.2f, max %.2f, stddev %.2f, histogram %v, %d legacy scores, legacy average %.2f",
+			rating.GetLaptopId(), rating.GetRateCount(), rating.GetAverageRate(),
+			rating.GetMinScore(), rating.GetMaxScore(), rating.GetStddevScore(), rating.GetHistogram(),
+			rating.GetLegacyRateCount(), rating.GetLegacyAverageRate(),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"library/v1/pb"
+	"library/v1/sample"
+	"library/v1/service"
+	"net"
+	"strings"
+	"testing"
+)
+
+func startTestLaptopServer(t *testing.T, laptopStore service.LaptopStore) pb.LaptopServiceClient {
+	grpcServer := grpc.NewServer()
+	pb.RegisterLaptopServiceServer(grpcServer, service.NewLaptopServer(laptopStore, nil, nil))
+
+	listener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewLaptopServiceClient(conn)
+}
+
+// TestCatalogFormat test the laptops written in each format are read back
+func TestCatalogFormat(t *testing.T) {
+	t.Parallel()
+
+	laptops := []*pb.Laptop{sample.NewLaptop(), sample.NewLaptop(), sample.NewLaptop()}
+
+	for _, format := range []catalogFormat{formatJSONL, formatJSON, formatBinary, formatYAML, formatCSV} {
+		format := format
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			buffer := &bytes.Buffer{}
+			writer := newLaptopWriter(buffer, format)
+			for _, laptop := range laptops {
+				require.NoError(t, writer.Write(laptop))
+			}
+			require.NoError(t, writer.Close())
+
+			reader := newLaptopReader(buffer, format)
+			for _, laptop := range laptops {
+				other, err := reader.Read()
+				require.NoError(t, err)
+				require.True(t, proto.Equal(laptop, other))
+			}
+			_, err := reader.Read()
+			require.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+// TestParseFormat test the format is guessed from the file extension
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	format, err := parseFormat("", "laptops.ndjson")
+	require.NoError(t, err)
+	require.Equal(t, formatJSONL, format)
+
+	format, err = parseFormat("binary", "laptops.json")
+	require.NoError(t, err)
+	require.Equal(t, formatBinary, format)
+
+	_, err = parseFormat("", "laptops.txt")
+	require.Error(t, err)
+	_, err = parseFormat("xml", "")
+	require.Error(t, err)
+}
+
+// TestExportImport test a catalog exported from a server is imported into another one
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+
+	source := service.NewInMemoryLaptopStore()
+	for i := 0; i < 20; i++ {
+		require.NoError(t, source.Save(sample.NewLaptop()))
+	}
+
+	buffer := &bytes.Buffer{}
+	writer := newLaptopWriter(buffer, formatJSONL)
+	err := exportFromServer(context.Background(), startTestLaptopServer(t, source), writer.Write)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	// a duplicate, a laptop with an invalid ID and a line that isn't a laptop
+	invalid := sample.NewLaptop()
+	invalid.Id = "invalid-id"
+	lines := strings.SplitAfter(buffer.String(), "\n")
+	writer = newLaptopWriter(buffer, formatJSONL)
+	buffer.WriteString(lines[0])
+	require.NoError(t, writer.Write(invalid))
+	require.NoError(t, writer.Close())
+	buffer.WriteString("{\"price_usd\": \"cheap\"}\n")
+	data := buffer.Bytes()
+
+	target := service.NewInMemoryLaptopStore()
+	laptopService := startTestLaptopServer(t, target)
+
+	type testCase struct {
+		name     string
+		importer *importer
+		report   importReport
+	}
+
+	// the test cases run in order: the dry runs don't create laptops
+	testCases := []testCase{
+		{
+			name:     "offline_dry_run",
+			importer: &importer{concurrency: 4, dryRun: true},
+			report:   importReport{DryRun: true, Created: 20, Skipped: 1, Failed: 2},
+		},
+		{
+			name:     "dry_run",
+			importer: &importer{laptopService: laptopService, concurrency: 4, dryRun: true},
+			report:   importReport{DryRun: true, Created: 20, Skipped: 1, Failed: 2},
+		},
+		{
+			name:     "import",
+			importer: &importer{laptopService: laptopService, concurrency: 4},
+			report:   importReport{Created: 20, Skipped: 1, Failed: 2},
+		},
+		{
+			name:     "import_again",
+			importer: &importer{laptopService: laptopService, concurrency: 4},
+			report:   importReport{Created: 0, Skipped: 21, Failed: 2},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			report, err := tc.importer.run(context.Background(), newLaptopReader(bytes.NewReader(data), formatJSONL))
+			require.NoError(t, err)
+			require.Equal(t, tc.report.DryRun, report.DryRun)
+			require.Equal(t, tc.report.Created, report.Created)
+			require.Equal(t, tc.report.Skipped, report.Skipped)
+			require.Equal(t, tc.report.Failed, report.Failed)
+			require.Equal(t, 22, report.Failures[0].Record)
+			require.Equal(t, "invalid-id", report.Failures[0].ID)
+			require.Equal(t, 23, report.Failures[1].Record)
+		})
+	}
+
+	count := 0
+	err = target.Search(context.Background(), nil, func(laptop *pb.Laptop) error {
+		other, err := source.Find(laptop.Id)
+		require.NoError(t, err)
+		require.Equal(t, other.GetName(), laptop.GetName())
+		count++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 20, count)
+}
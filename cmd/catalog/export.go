@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"library/v1/pb"
+	"library/v1/service"
+	"os"
+)
+
+// exportPageSize is the number of laptops of a SearchLaptop call,
+// the export doesn't keep a single stream open for the whole catalog
+const exportPageSize = 500
+
+// exportFromServer sends every laptop of the server to found, one search page at a time
+func exportFromServer(ctx context.Context, laptopService pb.LaptopServiceClient, found func(laptop *pb.Laptop) error) error {
+	pageToken := ""
+	for {
+		stream, err := laptopService.SearchLaptop(ctx, &pb.SearchLaptopRequest{
+			PageSize:  exportPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot search laptops: %w", err)
+		}
+
+		pageToken = ""
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("cannot receive laptop: %w", err)
+			}
+
+			err = found(res.GetLaptop())
+			if err != nil {
+				return err
+			}
+			pageToken = res.GetNextPageToken()
+		}
+
+		if pageToken == "" {
+			return nil
+		}
+	}
+}
+
+// exportFromStore sends every laptop of a file laptop store to found,
+// the server of the store must be stopped
+func exportFromStore(ctx context.Context, dir string, found func(laptop *pb.Laptop) error) error {
+	// the store would create a missing directory
+	_, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("cannot open store: %w", err)
+	}
+
+	store, err := service.NewFileLaptopStore(dir, service.FileLaptopStoreOptions{Sync: service.SyncNever})
+	if err != nil {
+		return fmt.Errorf("cannot open store: %w", err)
+	}
+	defer store.Close()
+
+	return store.Search(ctx, nil, found)
+}
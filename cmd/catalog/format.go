@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"library/v1/pb"
+	"library/v1/serializer"
+	"path/filepath"
+	"strings"
+)
+
+// catalogFormat is the file format of an exported catalog
+type catalogFormat string
+
+const (
+	// formatJSONL writes one JSON laptop per line
+	formatJSONL catalogFormat = "jsonl"
+	// formatJSON writes an indented JSON array of laptops
+	formatJSON catalogFormat = "json"
+	// formatBinary writes binary laptops, each one prefixed by its varint length
+	formatBinary catalogFormat = "binary"
+	// formatYAML writes a YAML document per laptop
+	formatYAML catalogFormat = "yaml"
+	// formatCSV writes a spreadsheet row per laptop
+	formatCSV catalogFormat = "csv"
+)
+
+// jsonOptions writes the laptops like the proto file names their fields
+var jsonOptions = serializer.JSONOptions{OrigName: true, Indent: "  "}
+
+// errInvalidRecord is returned for a record that cannot be decoded,
+// the reader can still read the next records
+var errInvalidRecord = serializer.ErrInvalidMessage
+
+// parseFormat returns the format named name, or the format of the filename extension if name is empty
+func parseFormat(name string, filename string) (catalogFormat, error) {
+	if name == "" {
+		switch strings.ToLower(filepath.Ext(filename)) {
+		case ".jsonl", ".ndjson":
+			return formatJSONL, nil
+		case ".json":
+			return formatJSON, nil
+		case ".bin", ".pb":
+			return formatBinary, nil
+		case ".yaml", ".yml":
+			return formatYAML, nil
+		case ".csv":
+			return formatCSV, nil
+		default:
+			return "", fmt.Errorf("cannot guess the format of %q, use -format", filename)
+		}
+	}
+
+	switch format := catalogFormat(strings.ToLower(name)); format {
+	case formatJSONL, formatJSON, formatBinary, formatYAML, formatCSV:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown format: %s", name)
+	}
+}
+
+// laptopWriter writes the laptops of a catalog file
+type laptopWriter interface {
+	Write(laptop *pb.Laptop) error
+	// Close ends the file and flushes it, it doesn't close the underlying writer
+	Close() error
+}
+
+// laptopReader reads the laptops of a catalog file,
+// Read returns io.EOF after the last laptop
+type laptopReader interface {
+	Read() (*pb.Laptop, error)
+}
+
+func newLaptopWriter(writer io.Writer, format catalogFormat) laptopWriter {
+	buffer := bufio.NewWriter(writer)
+	switch format {
+	case formatJSON:
+		return &jsonArrayWriter{writer: buffer}
+	case formatBinary:
+		return &streamWriter{writer: buffer, encoder: serializer.NewBinaryEncoder(buffer)}
+	case formatYAML:
+		return &streamWriter{writer: buffer, encoder: serializer.NewYAMLEncoder(buffer, jsonOptions)}
+	case formatCSV:
+		return &csvWriter{writer: buffer}
+	default:
+		return &streamWriter{writer: buffer, encoder: serializer.NewJSONEncoder(buffer, jsonOptions)}
+	}
+}
+
+func newLaptopReader(reader io.Reader, format catalogFormat) laptopReader {
+	switch format {
+	case formatJSON:
+		return &jsonArrayReader{decoder: json.NewDecoder(reader)}
+	case formatBinary:
+		return &streamReader{decoder: serializer.NewBinaryDecoder(reader)}
+	case formatYAML:
+		return &streamReader{decoder: serializer.NewYAMLDecoder(reader, jsonOptions)}
+	case formatCSV:
+		return &csvReader{decoder: serializer.NewCSVDecoder(reader)}
+	default:
+		return &streamReader{decoder: serializer.NewJSONDecoder(reader, jsonOptions)}
+	}
+}
+
+// streamWriter writes the JSONL, binary and YAML formats
+type streamWriter struct {
+	writer  *bufio.Writer
+	encoder *serializer.Encoder
+}
+
+func (w *streamWriter) Write(laptop *pb.Laptop) error {
+	return w.encoder.Encode(laptop)
+}
+
+func (w *streamWriter) Close() error {
+	return w.writer.Flush()
+}
+
+// streamReader reads the JSONL, binary and YAML formats
+type streamReader struct {
+	decoder *serializer.Decoder
+}
+
+func (r *streamReader) Read() (*pb.Laptop, error) {
+	laptop := &pb.Laptop{}
+	err := r.decoder.Decode(laptop)
+	if err != nil {
+		return nil, err
+	}
+	return laptop, nil
+}
+
+// csvWriter keeps the laptops until Close, the columns of the file depend on all their GPUs and storages
+type csvWriter struct {
+	writer  *bufio.Writer
+	laptops []*pb.Laptop
+}
+
+func (w *csvWriter) Write(laptop *pb.Laptop) error {
+	w.laptops = append(w.laptops, laptop)
+	return nil
+}
+
+func (w *csvWriter) Close() error {
+	err := serializer.WriteLaptopsToCSV(w.writer, w.laptops)
+	if err != nil {
+		return err
+	}
+	return w.writer.Flush()
+}
+
+type csvReader struct {
+	decoder *serializer.CSVDecoder
+}
+
+func (r *csvReader) Read() (*pb.Laptop, error) {
+	laptop := &pb.Laptop{}
+	err := r.decoder.Decode(laptop)
+	if err != nil {
+		return nil, err
+	}
+	return laptop, nil
+}
+
+type jsonArrayWriter struct {
+	writer *bufio.Writer
+	count  int
+}
+
+func (w *jsonArrayWriter) Write(laptop *pb.Laptop) error {
+	data, err := serializer.MarshalJSON(laptop, jsonOptions)
+	if err != nil {
+		return fmt.Errorf("cannot marshal laptop %s: %w", laptop.GetId(), err)
+	}
+
+	// the laptops are indented once more to be items of the array
+	separator := "[\n  "
+	if w.count > 0 {
+		separator = ",\n  "
+	}
+	w.count++
+	w.writer.WriteString(separator)
+	_, err = w.writer.Write(bytes.ReplaceAll(bytes.TrimSpace(data), []byte("\n"), []byte("\n  ")))
+	return err
+}
+
+func (w *jsonArrayWriter) Close() error {
+	if w.count == 0 {
+		w.writer.WriteString("[")
+	}
+	w.writer.WriteString("\n]\n")
+	return w.writer.Flush()
+}
+
+type jsonArrayReader struct {
+	decoder *json.Decoder
+	started bool
+}
+
+func (r *jsonArrayReader) Read() (*pb.Laptop, error) {
+	if !r.started {
+		token, err := r.decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read JSON array: %w", err)
+		}
+		if token != json.Delim('[') {
+			return nil, errors.New("file is not a JSON array")
+		}
+		r.started = true
+	}
+
+	if !r.decoder.More() {
+		_, err := r.decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read end of JSON array: %w", err)
+		}
+		return nil, io.EOF
+	}
+
+	var data json.RawMessage
+	err := r.decoder.Decode(&data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read JSON array item: %w", err)
+	}
+
+	laptop := &pb.Laptop{}
+	err = serializer.UnmarshalJSON(data, laptop, jsonOptions)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidRecord, err)
+	}
+	return laptop, nil
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"library/v1/pb"
+	"library/v1/service"
+	"sort"
+	"sync"
+	"time"
+)
+
+// importTimeout is the deadline of the call that imports one laptop
+const importTimeout = 10 * time.Second
+
+// importReport counts the records of an imported file
+type importReport struct {
+	DryRun   bool
+	Created  int
+	Skipped  int
+	Failed   int
+	Failures []importFailure
+}
+
+// importFailure is a record that couldn't be imported
+type importFailure struct {
+	Record int // position of the record in the file, from 1
+	ID     string
+	Err    error
+}
+
+// importer creates the laptops of a catalog file with CreateLaptop.
+// A dry run validates the file and only asks the server, if there is one, which laptops already exist.
+type importer struct {
+	laptopService pb.LaptopServiceClient
+	concurrency   int
+	dryRun        bool
+}
+
+// importJob is a laptop to create
+type importJob struct {
+	record int
+	laptop *pb.Laptop
+}
+
+// run imports the laptops of reader, it returns an error if the file cannot be read to the end
+func (im *importer) run(ctx context.Context, reader laptopReader) (*importReport, error) {
+	report := &importReport{DryRun: im.dryRun}
+	mutex := sync.Mutex{}
+	record := func(job importJob, err error, skipped bool) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		switch {
+		case err != nil:
+			report.Failed++
+			report.Failures = append(report.Failures, importFailure{Record: job.record, ID: job.laptop.GetId(), Err: err})
+		case skipped:
+			report.Skipped++
+		default:
+			report.Created++
+		}
+	}
+
+	concurrency := im.concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	jobs := make(chan importJob)
+	wg := sync.WaitGroup{}
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				skipped, err := im.importLaptop(ctx, job.laptop)
+				record(job, err, skipped)
+			}
+		}()
+	}
+
+	err := im.readJobs(ctx, reader, jobs, record)
+	close(jobs)
+	wg.Wait()
+
+	sort.Slice(report.Failures, func(i, j int) bool {
+		return report.Failures[i].Record < report.Failures[j].Record
+	})
+	return report, err
+}
+
+// readJobs validates the records of reader and sends the valid ones to jobs,
+// a laptop with the ID of a previous record is skipped
+func (im *importer) readJobs(
+	ctx context.Context,
+	reader laptopReader,
+	jobs chan<- importJob,
+	record func(job importJob, err error, skipped bool),
+) error {
+	ids := make(map[string]bool)
+	for n := 1; ; n++ {
+		laptop, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		job := importJob{record: n, laptop: laptop}
+		if errors.Is(err, errInvalidRecord) {
+			record(job, err, false)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("cannot read record %d: %w", n, err)
+		}
+
+		err = service.ValidateLaptop(laptop)
+		if err != nil {
+			record(job, err, false)
+			continue
+		}
+		if laptop.GetId() != "" {
+			if ids[laptop.GetId()] {
+				record(job, nil, true)
+				continue
+			}
+			ids[laptop.GetId()] = true
+		}
+
+		select {
+		case jobs <- job:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// importLaptop creates a laptop, it returns true if the laptop already exists
+func (im *importer) importLaptop(ctx context.Context, laptop *pb.Laptop) (bool, error) {
+	if im.dryRun && (im.laptopService == nil || laptop.GetId() == "") {
+		return false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, importTimeout)
+	defer cancel()
+
+	if im.dryRun {
+		_, err := im.laptopService.GetLaptop(ctx, &pb.GetLaptopRequest{Id: laptop.GetId()})
+		if status.Code(err) == codes.NotFound {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	_, err := im.laptopService.CreateLaptop(ctx, &pb.CreateLaptopRequest{Laptop: laptop})
+	if status.Code(err) == codes.AlreadyExists {
+		return true, nil
+	}
+	return false, err
+}
+
+// print writes the counts of the report and its failures
+func (report *importReport) print(writer io.Writer) {
+	if report.DryRun {
+		fmt.Fprintln(writer, "dry run, no laptop was created")
+		fmt.Fprintf(writer, "would create: %d, would skip: %d, failed: %d\n", report.Created, report.Skipped, report.Failed)
+	} else {
+		fmt.Fprintf(writer, "created: %d, skipped: %d, failed: %d\n", report.Created, report.Skipped, report.Failed)
+	}
+
+	for _, failure := range report.Failures {
+		if failure.ID != "" {
+			fmt.Fprintf(writer, "record %d (laptop %s): %v\n", failure.Record, failure.ID, failure.Err)
+		} else {
+			fmt.Fprintf(writer, "record %d: %v\n", failure.Record, failure.Err)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Command catalog exports the laptops of a server or of a file store and imports them back into a server.
+//
+//	catalog export -address localhost:8080 -out laptops.jsonl
+//	catalog export -store-dir data -format binary -out laptops.bin
+//	catalog import -address localhost:8080 -in laptops.jsonl -concurrency 8
+//	catalog import -in laptops.csv -dry-run
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"io"
+	"io/ioutil"
+	"library/v1/client"
+	"library/v1/pb"
+	"log"
+	"os"
+	"time"
+)
+
+const refreshDuration = 30 * time.Second
+
+func authMethod() map[string]bool {
+	const serverPath = "/techschool.proto.LaptopService/"
+	return map[string]bool{
+		serverPath + "CreateLaptop":       true,
+		serverPath + "BatchCreateLaptops": true,
+	}
+}
+
+func loadTLSCredentials() (credentials.TransportCredentials, error) {
+	// load certificate of the CA who signed server's certificate
+	pemServerCA, err := ioutil.ReadFile("certificate/ca-cert.pem")
+	if err != nil {
+		return nil, err
+	}
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(pemServerCA) {
+		return nil, fmt.Errorf("failed to add server CA's certificate")
+	}
+
+	clientCert, err := tls.LoadX509KeyPair("certificate/client-cert.pem", "certificate/client-key.pem")
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{clientCert},
+		RootCAs:      certPool,
+	}
+	return credentials.NewTLS(config), nil
+}
+
+// serverConfig is how to reach and log in to the laptop server
+type serverConfig struct {
+	address   string
+	enableTLS bool
+	username  string
+	password  string
+}
+
+func (config *serverConfig) addFlags(flags *flag.FlagSet) {
+	flags.StringVar(&config.address, "address", "", "address of the laptop server")
+	flags.BoolVar(&config.enableTLS, "tls", false, "enable SSL/TLS")
+	flags.StringVar(&config.username, "username", "user1", "user that creates the laptops")
+	flags.StringVar(&config.password, "password", "123456", "password of the user")
+}
+
+// dial connects to the server, the connection logs in for the methods that need it if login is true
+func (config *serverConfig) dial(login bool) (*grpc.ClientConn, error) {
+	transportOption := grpc.WithInsecure()
+	if config.enableTLS {
+		tlsCredentials, err := loadTLSCredentials()
+		if err != nil {
+			return nil, fmt.Errorf("cannot load TLS credentials: %w", err)
+		}
+		transportOption = grpc.WithTransportCredentials(tlsCredentials)
+	}
+
+	if !login {
+		return grpc.Dial(config.address, transportOption)
+	}
+
+	cc, err := grpc.Dial(config.address, transportOption)
+	if err != nil {
+		return nil, err
+	}
+	authClient := client.NewAuthClient(cc, config.username, config.password)
+	interceptor, err := client.NewAuthInterceptor(authClient, authMethod(), refreshDuration)
+	if err != nil {
+		cc.Close()
+		return nil, fmt.Errorf("cannot log in: %w", err)
+	}
+
+	return grpc.Dial(
+		config.address,
+		transportOption,
+		grpc.WithUnaryInterceptor(interceptor.Unary()),
+		grpc.WithStreamInterceptor(interceptor.Stream()),
+	)
+}
+
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	server := serverConfig{}
+	server.addFlags(flags)
+	storeDir := flags.String("store-dir", "", "directory of a file laptop store to export instead of a server")
+	formatName := flags.String("format", "", "format of the file (jsonl/json/binary/yaml/csv), guessed from -out by default")
+	out := flags.String("out", "", "file to write, standard output by default")
+	flags.Parse(args)
+
+	if (server.address == "") == (*storeDir == "") {
+		return fmt.Errorf("export needs one of -address or -store-dir")
+	}
+	format := formatJSONL
+	if *formatName != "" || *out != "" {
+		var err error
+		format, err = parseFormat(*formatName, *out)
+		if err != nil {
+			return err
+		}
+	}
+
+	var writer io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("cannot create output file: %w", err)
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	laptopWriter := newLaptopWriter(writer, format)
+	count := 0
+	found := func(laptop *pb.Laptop) error {
+		count++
+		return laptopWriter.Write(laptop)
+	}
+
+	ctx := context.Background()
+	if *storeDir != "" {
+		err := exportFromStore(ctx, *storeDir, found)
+		if err != nil {
+			return err
+		}
+	} else {
+		cc, err := server.dial(false)
+		if err != nil {
+			return fmt.Errorf("cannot dial server: %w", err)
+		}
+		defer cc.Close()
+
+		err = exportFromServer(ctx, pb.NewLaptopServiceClient(cc), found)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := laptopWriter.Close()
+	if err != nil {
+		return fmt.Errorf("cannot write output file: %w", err)
+	}
+	log.Printf("exported %d laptops", count)
+	return nil
+}
+
+func runImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	server := serverConfig{}
+	server.addFlags(flags)
+	formatName := flags.String("format", "", "format of the file (jsonl/json/binary/yaml/csv), guessed from -in by default")
+	in := flags.String("in", "", "file to import")
+	concurrency := flags.Int("concurrency", 4, "number of laptops created at the same time")
+	dryRun := flags.Bool("dry-run", false, "validate the file without creating laptops, -address also reports existing laptops")
+	flags.Parse(args)
+
+	if *in == "" {
+		return fmt.Errorf("import needs -in")
+	}
+	if server.address == "" && !*dryRun {
+		return fmt.Errorf("import needs -address unless it is a dry run")
+	}
+	format, err := parseFormat(*formatName, *in)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(*in)
+	if err != nil {
+		return fmt.Errorf("cannot open input file: %w", err)
+	}
+	defer file.Close()
+
+	im := &importer{concurrency: *concurrency, dryRun: *dryRun}
+	if server.address != "" {
+		cc, err := server.dial(!*dryRun)
+		if err != nil {
+			return fmt.Errorf("cannot dial server: %w", err)
+		}
+		defer cc.Close()
+		im.laptopService = pb.NewLaptopServiceClient(cc)
+	}
+
+	report, err := im.run(context.Background(), newLaptopReader(file, format))
+	report.print(os.Stdout)
+	if err != nil {
+		return err
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("%d records failed", report.Failed)
+	}
+	return nil
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: catalog export|import [flags]")
+	fmt.Fprintln(os.Stderr, "run catalog export -h or catalog import -h for the flags")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "export":
+		err = runExport(os.Args[2:])
+	case "import":
+		err = runImport(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
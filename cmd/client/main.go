@@ -38,10 +38,13 @@ func testSearchLaptop(laptopClient *client.LaptopClient) {
 func testUploadImage(laptopClient *client.LaptopClient) {
 	laptop := sample.NewLaptop()
 	laptopClient.CreateLaptop(laptop)
-	laptopClient.UploadImage(laptop.GetId(), "tmp/laptop.png")
+	_, err := laptopClient.UploadImage(laptop.GetId(), "tmp/laptop.png")
+	if err != nil {
+		log.Fatal(err)
+	}
 }
 
-func testRatingLaptop(laptopClient *client.LaptopClient) {
+func testRateLaptop(laptopClient *client.LaptopClient) {
 	n := 3
 	// create laptopIDS and createLaptop store in memoryStore
 	laptopIDs := make([]string, n)
@@ -64,7 +67,11 @@ func testRatingLaptop(laptopClient *client.LaptopClient) {
 			scores[i] = sample.RandomLaptopScore()
 		}
 
-		err := laptopClient.RatingLaptop(laptopIDs, scores)
+		err := laptopClient.RateLaptop(laptopIDs, scores)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = laptopClient.GetRatings(laptopIDs)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -80,9 +87,18 @@ const (
 func authMethod() map[string]bool {
 	const serverPath = "/techschool.proto.LaptopService/"
 	return map[string]bool{
-		serverPath + "CreateLaptop": true,
-		serverPath + "UploadImage":  true,
-		serverPath + "RatingLaptop": true,
+		serverPath + "CreateLaptop":       true,
+		serverPath + "BatchCreateLaptops": true,
+		serverPath + "UpdateLaptop":       true,
+		serverPath + "DeleteLaptop":       true,
+		serverPath + "UploadImage":        true,
+		serverPath + "DeleteImage":        true,
+		serverPath + "InitiateUpload":     true,
+		serverPath + "UploadChunks":       true,
+		serverPath + "QueryUpload":        true,
+		serverPath + "CommitUpload":       true,
+		serverPath + "RateLaptop":         true,
+		serverPath + "DeleteRating":       true,
 	}
 }
 
@@ -152,5 +168,5 @@ func main() {
 	}
 
 	laptopClient := client.NewLaptopClient(cc2)
-	testRatingLaptop(laptopClient)
+	testRateLaptop(laptopClient)
 }
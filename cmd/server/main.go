@@ -4,18 +4,27 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"database/sql"
+	"errors"
 	"flag"
 	"fmt"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
+	"io"
 	"io/ioutil"
 	"library/v1/pb"
 	"library/v1/service"
 	"log"
+	_ "modernc.org/sqlite"
 	net2 "net"
 	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"time"
 )
 
@@ -43,15 +52,29 @@ func CreateUser(userStore service.UserStore, username string, password string, r
 		return err
 	}
 
-	return userStore.Save(user)
+	// users of a persistent store are seeded by a previous start
+	err = userStore.Save(user)
+	if errors.Is(err, service.ErrAlreadyExists) {
+		return nil
+	}
+	return err
 }
 
 func accessibleRoles() map[string][]string {
 	const serverPath = "/techschool.proto.LaptopService/"
 	return map[string][]string{
-		serverPath + "CreateLaptop": {"admin"},
-		serverPath + "UploadImage":  {"admin"},
-		serverPath + "RatingLaptop": {"admin", "user"},
+		serverPath + "CreateLaptop":       {"admin"},
+		serverPath + "BatchCreateLaptops": {"admin"},
+		serverPath + "UpdateLaptop":       {"admin"},
+		serverPath + "DeleteLaptop":       {"admin"},
+		serverPath + "UploadImage":        {"admin"},
+		serverPath + "DeleteImage":        {"admin"},
+		serverPath + "InitiateUpload":     {"admin"},
+		serverPath + "UploadChunks":       {"admin"},
+		serverPath + "QueryUpload":        {"admin"},
+		serverPath + "CommitUpload":       {"admin"},
+		serverPath + "RateLaptop":         {"admin", "user"},
+		serverPath + "DeleteRating":       {"admin", "user"},
 	}
 }
 
@@ -82,6 +105,7 @@ func loadTLSCredentials() (credentials.TransportCredentials, error) {
 }
 
 func rungRPCServer(
+	ctx context.Context,
 	authServer pb.AuthServiceServer,
 	laptopServer pb.LaptopServiceServer,
 	jwtManager *service.JWTManager,
@@ -109,39 +133,149 @@ func rungRPCServer(
 	pb.RegisterLaptopServiceServer(grpcServer, laptopServer)
 	reflection.Register(grpcServer)
 
+	// the calls in progress complete when the server is stopped
+	go func() {
+		<-ctx.Done()
+		grpcServer.GracefulStop()
+	}()
+
 	// Start grpc server
 	log.Printf("Start gRPC server at %s, TLS=%t", listener.Addr().String(), enableTLS)
 	return grpcServer.Serve(listener)
 }
 
+// incomingHeaderMatcher passes the If-Match header to the laptop service as is
+func incomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, service.IfMatchHeader) {
+		return service.IfMatchHeader, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher returns the laptop revision as a standard ETag header
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == service.ETagHeader {
+		return "ETag", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
 func runRESTServer(
+	ctx context.Context,
 	authServer pb.AuthServiceServer,
 	laptopServer pb.LaptopServiceServer,
 	jwtManager *service.JWTManager,
 	enableTLS bool,
 	listener net2.Listener,
 ) error {
-	mux := runtime.NewServeMux()
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+	)
 	err := pb.RegisterAuthServiceHandlerServer(ctx, mux, authServer)
 	if err != nil {
 		return err
 	}
 
-	err = pb.RegisterLaptopServiceHandlerServer(ctx, mux, laptopServer)
+	// the gateway calls the laptop server in-process, it checks the roles without the gRPC interceptors
+	interceptor := service.NewAuthInterceptor(jwtManager, accessibleRoles())
+	err = pb.RegisterLaptopServiceHandlerServer(ctx, mux, service.NewGatewayLaptopServer(laptopServer, interceptor))
 	if err != nil {
 		return err
 	}
 
+	// the requests in progress complete when the server is stopped
+	server := &http.Server{Handler: mux}
+	go func() {
+		<-ctx.Done()
+		server.Shutdown(context.Background())
+	}()
+
 	log.Printf("Start REST server at %s, TLS=%t", listener.Addr().String(), enableTLS)
 
 	if enableTLS {
-		return http.ServeTLS(listener, mux, serverCertPem, serverKeyPem)
+		err = server.ServeTLS(listener, serverCertPem, serverKeyPem)
+	} else {
+		err = server.Serve(listener)
+	}
+	if err == http.ErrServerClosed {
+		return nil
 	}
+	return err
+}
 
-	return http.Serve(listener, mux)
+// storeConfig selects and configures the stores of the server
+type storeConfig struct {
+	storeType     string
+	storeDir      string
+	syncPolicy    string
+	syncInterval  time.Duration
+	snapshotEvery int
+	dbDriver      string
+	dbSource      string
+}
+
+func newStores(config storeConfig) (service.LaptopStore, service.RatingStore, service.UserStore, error) {
+	switch config.storeType {
+	case "memory":
+		return service.NewInMemoryLaptopStore(), service.NewInMemoryRatingStore(), service.NewInMemoryUserStore(), nil
+	case "file":
+		policy, err := service.ParseSyncPolicy(config.syncPolicy)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		laptopStore, err := service.NewFileLaptopStore(config.storeDir, service.FileLaptopStoreOptions{
+			Sync:          policy,
+			SyncInterval:  config.syncInterval,
+			SnapshotEvery: config.snapshotEvery,
+		})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return laptopStore, service.NewInMemoryRatingStore(), service.NewInMemoryUserStore(), nil
+	case "sql":
+		db, err := sql.Open(config.dbDriver, config.dbSource)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("cannot open database: %w", err)
+		}
+		err = service.MigrateDatabase(db)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return service.NewSQLLaptopStore(db), service.NewSQLRatingStore(db), service.NewSQLUserStore(db), nil
+	default:
+		return nil, nil, nil, fmt.Errorf("unknown store type: %s", config.storeType)
+	}
+}
+
+// blobConfig selects and configures the store of the image data
+type blobConfig struct {
+	blobType   string
+	s3Endpoint string
+	s3Region   string
+	s3Bucket   string
+	s3Prefix   string
+	s3PartSize int64
+}
+
+func newBlobStore(config blobConfig, imageDir string) (service.BlobStore, error) {
+	switch config.blobType {
+	case "local":
+		return service.NewLocalBlobStore(filepath.Join(imageDir, "blobs")), nil
+	case "s3":
+		// the credentials are read from the environment like the AWS tools
+		return service.NewS3BlobStore(service.S3Config{
+			Endpoint:        config.s3Endpoint,
+			Region:          config.s3Region,
+			Bucket:          config.s3Bucket,
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			Prefix:          config.s3Prefix,
+			PartSize:        config.s3PartSize,
+		})
+	default:
+		return nil, fmt.Errorf("unknown blob store type: %s", config.blobType)
+	}
 }
 
 func main() {
@@ -149,18 +283,64 @@ func main() {
 	port := flag.Int("port", 0, "the server port")
 	enableTLS := flag.Bool("tls", false, "enable SSL/TLS")
 	serverType := flag.String("type", "grpc", "type of server (grpc/rest)")
+	config := storeConfig{}
+	flag.StringVar(&config.storeType, "store", "memory", "type of store (memory/file/sql)")
+	flag.StringVar(&config.storeDir, "store-dir", "data", "directory of the file laptop store")
+	flag.StringVar(&config.syncPolicy, "fsync", "always", "fsync policy of the file laptop store (always/interval/never)")
+	flag.DurationVar(&config.syncInterval, "fsync-interval", time.Second, "fsync period of the interval policy")
+	flag.IntVar(&config.snapshotEvery, "snapshot-every", 1000, "compact the file laptop store after this many mutations")
+	flag.StringVar(&config.dbDriver, "db-driver", "sqlite", "database/sql driver of the sql store")
+	flag.StringVar(&config.dbSource, "db-source", "laptop.db", "data source name of the sql store")
+	maxImageSize := flag.Int64("max-image-size", service.MaxImageSize, "largest size in bytes of an uploaded image")
+	imageDir := flag.String("image-dir", "img", "directory of the images being uploaded, and of their data with the local blob store")
+	blobs := blobConfig{}
+	flag.StringVar(&blobs.blobType, "blob-store", "local", "type of store of the image data (local/s3)")
+	flag.StringVar(&blobs.s3Endpoint, "s3-endpoint", "https://s3.amazonaws.com", "URL of the S3 compatible service")
+	flag.StringVar(&blobs.s3Region, "s3-region", "us-east-1", "region of the S3 bucket")
+	flag.StringVar(&blobs.s3Bucket, "s3-bucket", "", "bucket of the image data")
+	flag.StringVar(&blobs.s3Prefix, "s3-prefix", "", "prefix of the keys of the image data in the bucket")
+	flag.Int64Var(&blobs.s3PartSize, "s3-part-size", service.MinS3PartSize, "size in bytes of the parts of the S3 multipart uploads")
+	imageGCInterval := flag.Duration("image-gc-interval", 0, "period of the removal of the image garbage, 0 disables it, requires a persistent laptop store")
+	imageGCDryRun := flag.Bool("image-gc-dry-run", false, "log the image garbage without removing it")
 	flag.Parse()
 	log.Printf("start server on port %d, TLS=%t\n", *port, *enableTLS)
 
+	// the server stops on interrupt, and closes its stores
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Create a laptop service and  grpcServer
 	// after, register laptop service in grpc server
-	laptopStore := service.NewInMemoryLaptopStore()
-	imageStore := service.NewDiskImageStore("img")
-	ratingStore := service.NewInMemoryRatingStore()
-	laptopServer := service.NewLaptopServer(laptopStore, imageStore, ratingStore)
+	laptopStore, ratingStore, userStore, err := newStores(config)
+	if err != nil {
+		log.Fatalf("cannot create stores: %s", err)
+	}
+	blobStore, err := newBlobStore(blobs, *imageDir)
+	if err != nil {
+		log.Fatalf("cannot create blob store: %s", err)
+	}
+	imageStore, err := service.LoadDiskImageStore(*imageDir, service.WithBlobStore(blobStore))
+	if err != nil {
+		log.Fatalf("cannot load image store: %s", err)
+	}
+	if *imageGCInterval > 0 {
+		// the laptops of a memory store are lost on restart, their images would be removed as garbage
+		if config.storeType == "memory" {
+			log.Fatal("cannot collect image garbage: the memory laptop store is not persistent")
+		}
+		go imageStore.RunGarbageCollector(ctx, laptopStore, *imageGCInterval, *imageGCDryRun)
+	}
+	// the uploads are kept with the image data, so that an upload is resumed on any server sharing it
+	uploadStore := service.NewBlobUploadStore(blobStore)
+	laptopServer := service.NewLaptopServer(
+		laptopStore,
+		imageStore,
+		ratingStore,
+		service.WithMaxImageSize(*maxImageSize),
+		service.WithUploadStore(uploadStore),
+	)
 
-	userStore := service.NewInMemoryUserStore()
-	err := seedUser(userStore)
+	err = seedUser(userStore)
 	if err != nil {
 		log.Fatalf("cannot seed user: %s", err)
 	}
@@ -174,15 +354,25 @@ func main() {
 		log.Fatal("cannot start a listener: ", err)
 	}
 	if *serverType == "grpc" {
-		err = rungRPCServer(authServer, laptopServer, jwtManager, *enableTLS, listener)
+		err = rungRPCServer(ctx, authServer, laptopServer, jwtManager, *enableTLS, listener)
 		if err != nil {
-			log.Fatal("not start gRPC server")
+			err = fmt.Errorf("not start gRPC server: %w", err)
 		}
 	} else {
-		err = runRESTServer(authServer, laptopServer, jwtManager, *enableTLS, listener)
+		err = runRESTServer(ctx, authServer, laptopServer, jwtManager, *enableTLS, listener)
 		if err != nil {
-			log.Fatal("not start rest server")
+			err = fmt.Errorf("not start rest server: %w", err)
 		}
 	}
 
+	// the file laptop store flushes its log when it is closed
+	if closer, ok := laptopStore.(io.Closer); ok {
+		closeErr := closer.Close()
+		if closeErr != nil {
+			log.Printf("cannot close laptop store: %v", closeErr)
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"library/v1/pb"
+	"library/v1/sample"
+	"library/v1/service"
+	"net"
+	"testing"
+)
+
+// TestAccessibleRoles test the laptops are only created by the admins, one by one or in batch
+func TestAccessibleRoles(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	jwtManager := service.NewJWTManager(secretKey, timeDuration)
+	interceptor := service.NewAuthInterceptor(jwtManager, accessibleRoles())
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(interceptor.Unary()),
+		grpc.StreamInterceptor(interceptor.Stream()),
+	)
+	pb.RegisterLaptopServiceServer(grpcServer, service.NewLaptopServer(laptopStore, nil, nil))
+	listener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	laptopClient := pb.NewLaptopServiceClient(conn)
+
+	roleContext := func(role string) context.Context {
+		if role == "" {
+			return context.Background()
+		}
+		user, err := service.NewUser("user1", "secret", role)
+		require.NoError(t, err)
+		token, err := jwtManager.Generate(user)
+		require.NoError(t, err)
+		return metadata.AppendToOutgoingContext(context.Background(), "authorization", token)
+	}
+	batchCreate := func(ctx context.Context) error {
+		stream, err := laptopClient.BatchCreateLaptops(ctx)
+		require.NoError(t, err)
+		// the send to a rejected stream returns io.EOF, the status is received by CloseAndRecv
+		stream.Send(&pb.BatchCreateLaptopsRequest{Laptops: []*pb.Laptop{sample.NewLaptop()}})
+		_, err = stream.CloseAndRecv()
+		return err
+	}
+
+	testCases := []struct {
+		name string
+		role string
+		code codes.Code
+	}{
+		{name: "anonymous", code: codes.Unauthenticated},
+		{name: "user", role: "user", code: codes.Unauthenticated},
+		{name: "admin", role: "admin", code: codes.OK},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := laptopClient.CreateLaptop(roleContext(tc.role), &pb.CreateLaptopRequest{Laptop: sample.NewLaptop()})
+			require.Equal(t, tc.code, status.Code(err))
+			err = batchCreate(roleContext(tc.role))
+			require.Equal(t, tc.code, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,228 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        v3.17.3
+// source: proto/order_message.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Order_Field int32
+
+const (
+	Order_UNKNOWN        Order_Field = 0
+	Order_PRICE          Order_Field = 1
+	Order_CPU_GHZ        Order_Field = 2
+	Order_RAM            Order_Field = 3
+	Order_RELEASE_YEAR   Order_Field = 4
+	Order_AVERAGE_RATING Order_Field = 5
+	// Text relevance score, only for requests with text
+	Order_RELEVANCE Order_Field = 6
+)
+
+// Enum value maps for Order_Field.
+var (
+	Order_Field_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "PRICE",
+		2: "CPU_GHZ",
+		3: "RAM",
+		4: "RELEASE_YEAR",
+		5: "AVERAGE_RATING",
+		6: "RELEVANCE",
+	}
+	Order_Field_value = map[string]int32{
+		"UNKNOWN":        0,
+		"PRICE":          1,
+		"CPU_GHZ":        2,
+		"RAM":            3,
+		"RELEASE_YEAR":   4,
+		"AVERAGE_RATING": 5,
+		"RELEVANCE":      6,
+	}
+)
+
+func (x Order_Field) Enum() *Order_Field {
+	p := new(Order_Field)
+	*p = x
+	return p
+}
+
+func (x Order_Field) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Order_Field) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_order_message_proto_enumTypes[0].Descriptor()
+}
+
+func (Order_Field) Type() protoreflect.EnumType {
+	return &file_proto_order_message_proto_enumTypes[0]
+}
+
+func (x Order_Field) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Order_Field.Descriptor instead.
+func (Order_Field) EnumDescriptor() ([]byte, []int) {
+	return file_proto_order_message_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// Order used to sort searched laptops, laptops with the same key are sorted by id
+type Order struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field      Order_Field `protobuf:"varint,1,opt,name=field,proto3,enum=techschool.proto.Order_Field" json:"field,omitempty"`
+	Descending bool        `protobuf:"varint,2,opt,name=descending,proto3" json:"descending,omitempty"`
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_order_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_proto_order_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Order) GetField() Order_Field {
+	if x != nil {
+		return x.Field
+	}
+	return Order_UNKNOWN
+}
+
+func (x *Order) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+var File_proto_order_message_proto protoreflect.FileDescriptor
+
+var file_proto_order_message_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x74, 0x65, 0x63,
+	0x68, 0x73, 0x63, 0x68, 0x6f, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc8, 0x01,
+	0x0a, 0x05, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x33, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x74, 0x65, 0x63, 0x68, 0x73, 0x63, 0x68,
+	0x6f, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x2e,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1e, 0x0a, 0x0a,
+	0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x6a, 0x0a, 0x05,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
+	0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x52, 0x49, 0x43, 0x45, 0x10, 0x01, 0x12, 0x0b, 0x0a,
+	0x07, 0x43, 0x50, 0x55, 0x5f, 0x47, 0x48, 0x5a, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x41,
+	0x4d, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x45, 0x4c, 0x45, 0x41, 0x53, 0x45, 0x5f, 0x59,
+	0x45, 0x41, 0x52, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x56, 0x45, 0x52, 0x41, 0x47, 0x45,
+	0x5f, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x05, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x45, 0x4c,
+	0x45, 0x56, 0x41, 0x4e, 0x43, 0x45, 0x10, 0x06, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_order_message_proto_rawDescOnce sync.Once
+	file_proto_order_message_proto_rawDescData = file_proto_order_message_proto_rawDesc
+)
+
+func file_proto_order_message_proto_rawDescGZIP() []byte {
+	file_proto_order_message_proto_rawDescOnce.Do(func() {
+		file_proto_order_message_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_order_message_proto_rawDescData)
+	})
+	return file_proto_order_message_proto_rawDescData
+}
+
+var file_proto_order_message_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_order_message_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_proto_order_message_proto_goTypes = []interface{}{
+	(Order_Field)(0), // 0: techschool.proto.Order.Field
+	(*Order)(nil),    // 1: techschool.proto.Order
+}
+var file_proto_order_message_proto_depIdxs = []int32{
+	0, // 0: techschool.proto.Order.field:type_name -> techschool.proto.Order.Field
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_order_message_proto_init() }
+func file_proto_order_message_proto_init() {
+	if File_proto_order_message_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_order_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Order); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_order_message_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_order_message_proto_goTypes,
+		DependencyIndexes: file_proto_order_message_proto_depIdxs,
+		EnumInfos:         file_proto_order_message_proto_enumTypes,
+		MessageInfos:      file_proto_order_message_proto_msgTypes,
+	}.Build()
+	File_proto_order_message_proto = out.File
+	file_proto_order_message_proto_rawDesc = nil
+	file_proto_order_message_proto_goTypes = nil
+	file_proto_order_message_proto_depIdxs = nil
+}
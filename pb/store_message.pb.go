@@ -0,0 +1,337 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        v3.17.3
+// source: proto/store_message.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LaptopRecord_Operation int32
+
+const (
+	LaptopRecord_UNKNOWN  LaptopRecord_Operation = 0
+	LaptopRecord_SAVE     LaptopRecord_Operation = 1
+	LaptopRecord_UPDATE   LaptopRecord_Operation = 2
+	LaptopRecord_DELETE   LaptopRecord_Operation = 3
+	LaptopRecord_SAVE_ALL LaptopRecord_Operation = 4
+)
+
+// Enum value maps for LaptopRecord_Operation.
+var (
+	LaptopRecord_Operation_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "SAVE",
+		2: "UPDATE",
+		3: "DELETE",
+		4: "SAVE_ALL",
+	}
+	LaptopRecord_Operation_value = map[string]int32{
+		"UNKNOWN":  0,
+		"SAVE":     1,
+		"UPDATE":   2,
+		"DELETE":   3,
+		"SAVE_ALL": 4,
+	}
+)
+
+func (x LaptopRecord_Operation) Enum() *LaptopRecord_Operation {
+	p := new(LaptopRecord_Operation)
+	*p = x
+	return p
+}
+
+func (x LaptopRecord_Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LaptopRecord_Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_store_message_proto_enumTypes[0].Descriptor()
+}
+
+func (LaptopRecord_Operation) Type() protoreflect.EnumType {
+	return &file_proto_store_message_proto_enumTypes[0]
+}
+
+func (x LaptopRecord_Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LaptopRecord_Operation.Descriptor instead.
+func (LaptopRecord_Operation) EnumDescriptor() ([]byte, []int) {
+	return file_proto_store_message_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// LaptopRecord is one mutation of the laptop store written to the write-ahead log
+type LaptopRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sequence  uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Operation LaptopRecord_Operation `protobuf:"varint,2,opt,name=operation,proto3,enum=techschool.proto.LaptopRecord_Operation" json:"operation,omitempty"`
+	// The laptop after a save or update
+	Laptop *Laptop `protobuf:"bytes,3,opt,name=laptop,proto3" json:"laptop,omitempty"`
+	// The laptop id of a delete
+	Id string `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	// The laptops of an atomic batch save
+	Laptops []*Laptop `protobuf:"bytes,5,rep,name=laptops,proto3" json:"laptops,omitempty"`
+}
+
+func (x *LaptopRecord) Reset() {
+	*x = LaptopRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_store_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LaptopRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LaptopRecord) ProtoMessage() {}
+
+func (x *LaptopRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_store_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LaptopRecord.ProtoReflect.Descriptor instead.
+func (*LaptopRecord) Descriptor() ([]byte, []int) {
+	return file_proto_store_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LaptopRecord) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *LaptopRecord) GetOperation() LaptopRecord_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return LaptopRecord_UNKNOWN
+}
+
+func (x *LaptopRecord) GetLaptop() *Laptop {
+	if x != nil {
+		return x.Laptop
+	}
+	return nil
+}
+
+func (x *LaptopRecord) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LaptopRecord) GetLaptops() []*Laptop {
+	if x != nil {
+		return x.Laptops
+	}
+	return nil
+}
+
+// LaptopSnapshot is the compacted content of the laptop store
+type LaptopSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Sequence of the last record included in the snapshot
+	Sequence uint64    `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Laptops  []*Laptop `protobuf:"bytes,2,rep,name=laptops,proto3" json:"laptops,omitempty"`
+}
+
+func (x *LaptopSnapshot) Reset() {
+	*x = LaptopSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_store_message_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LaptopSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LaptopSnapshot) ProtoMessage() {}
+
+func (x *LaptopSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_store_message_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LaptopSnapshot.ProtoReflect.Descriptor instead.
+func (*LaptopSnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_store_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LaptopSnapshot) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *LaptopSnapshot) GetLaptops() []*Laptop {
+	if x != nil {
+		return x.Laptops
+	}
+	return nil
+}
+
+var File_proto_store_message_proto protoreflect.FileDescriptor
+
+var file_proto_store_message_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x74, 0x65, 0x63,
+	0x68, 0x73, 0x63, 0x68, 0x6f, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1a, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x5f, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb2, 0x02, 0x0a, 0x0c, 0x4c, 0x61,
+	0x70, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x74, 0x65, 0x63, 0x68,
+	0x73, 0x63, 0x68, 0x6f, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x61, 0x70,
+	0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x30,
+	0x0a, 0x06, 0x6c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x74, 0x65, 0x63, 0x68, 0x73, 0x63, 0x68, 0x6f, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x52, 0x06, 0x6c, 0x61, 0x70, 0x74, 0x6f, 0x70,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x32, 0x0a, 0x07, 0x6c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x74, 0x65, 0x63, 0x68, 0x73, 0x63, 0x68, 0x6f, 0x6f, 0x6c, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x52, 0x07, 0x6c, 0x61, 0x70,
+	0x74, 0x6f, 0x70, 0x73, 0x22, 0x48, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x08,
+	0x0a, 0x04, 0x53, 0x41, 0x56, 0x45, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x44, 0x41,
+	0x54, 0x45, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x03,
+	0x12, 0x0c, 0x0a, 0x08, 0x53, 0x41, 0x56, 0x45, 0x5f, 0x41, 0x4c, 0x4c, 0x10, 0x04, 0x22, 0x60,
+	0x0a, 0x0e, 0x4c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x32, 0x0a, 0x07,
+	0x6c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x74, 0x65, 0x63, 0x68, 0x73, 0x63, 0x68, 0x6f, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x4c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x52, 0x07, 0x6c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x73,
+	0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_store_message_proto_rawDescOnce sync.Once
+	file_proto_store_message_proto_rawDescData = file_proto_store_message_proto_rawDesc
+)
+
+func file_proto_store_message_proto_rawDescGZIP() []byte {
+	file_proto_store_message_proto_rawDescOnce.Do(func() {
+		file_proto_store_message_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_store_message_proto_rawDescData)
+	})
+	return file_proto_store_message_proto_rawDescData
+}
+
+var file_proto_store_message_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_store_message_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_store_message_proto_goTypes = []interface{}{
+	(LaptopRecord_Operation)(0), // 0: techschool.proto.LaptopRecord.Operation
+	(*LaptopRecord)(nil),        // 1: techschool.proto.LaptopRecord
+	(*LaptopSnapshot)(nil),      // 2: techschool.proto.LaptopSnapshot
+	(*Laptop)(nil),              // 3: techschool.proto.Laptop
+}
+var file_proto_store_message_proto_depIdxs = []int32{
+	0, // 0: techschool.proto.LaptopRecord.operation:type_name -> techschool.proto.LaptopRecord.Operation
+	3, // 1: techschool.proto.LaptopRecord.laptop:type_name -> techschool.proto.Laptop
+	3, // 2: techschool.proto.LaptopRecord.laptops:type_name -> techschool.proto.Laptop
+	3, // 3: techschool.proto.LaptopSnapshot.laptops:type_name -> techschool.proto.Laptop
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_proto_store_message_proto_init() }
+func file_proto_store_message_proto_init() {
+	if File_proto_store_message_proto != nil {
+		return
+	}
+	file_proto_laptop_message_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_proto_store_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LaptopRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_store_message_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LaptopSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_store_message_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_store_message_proto_goTypes,
+		DependencyIndexes: file_proto_store_message_proto_depIdxs,
+		EnumInfos:         file_proto_store_message_proto_enumTypes,
+		MessageInfos:      file_proto_store_message_proto_msgTypes,
+	}.Build()
+	File_proto_store_message_proto = out.File
+	file_proto_store_message_proto_rawDesc = nil
+	file_proto_store_message_proto_goTypes = nil
+	file_proto_store_message_proto_depIdxs = nil
+}
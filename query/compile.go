@@ -0,0 +1,369 @@
+package query
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+)
+
+// evaluator is the compiled form of an expression
+type evaluator func(m protoreflect.Message) bool
+
+// predicate is the compiled form of a comparison with a field value
+type predicate func(v protoreflect.Value) bool
+
+// unitBits is the size in bits of the memory units, by the names of the values of the unit enum
+var unitBits = map[protoreflect.Name]uint64{
+	"BIT":      1,
+	"BYTE":     8,
+	"KILOBYTE": 8 << 10,
+	"MEGABYTE": 8 << 20,
+	"GIGABYTE": 8 << 30,
+	"TERABYTE": 8 << 40,
+}
+
+// compiler type checks a syntax tree and turns it into an evaluator
+type compiler struct {
+	source     string
+	descriptor protoreflect.MessageDescriptor
+	// memory is compared with memory literals instead of field by field, nil if there is none
+	memory protoreflect.MessageDescriptor
+	// foldCase are the string fields compared without case
+	foldCase map[protoreflect.FullName]bool
+}
+
+// Option configures the compilation of a query
+type Option func(*compiler)
+
+// WithMemory compares the messages of descriptor with memory sizes such as 16GB,
+// they have a value field and a unit enum field with values named BIT, BYTE, KILOBYTE ... TERABYTE
+func WithMemory(descriptor protoreflect.MessageDescriptor) Option {
+	return func(c *compiler) {
+		c.memory = descriptor
+	}
+}
+
+// WithFoldCase compares the values of the string fields without case
+func WithFoldCase(fields ...protoreflect.FullName) Option {
+	return func(c *compiler) {
+		for _, field := range fields {
+			c.foldCase[field] = true
+		}
+	}
+}
+
+func (c *compiler) compile(n node) (evaluator, error) {
+	switch n := n.(type) {
+	case *binaryNode:
+		left, err := c.compile(n.left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := c.compile(n.right)
+		if err != nil {
+			return nil, err
+		}
+		if n.op.kind == tokenAnd {
+			return func(m protoreflect.Message) bool { return left(m) && right(m) }, nil
+		}
+		return func(m protoreflect.Message) bool { return left(m) || right(m) }, nil
+	case *notNode:
+		expr, err := c.compile(n.expr)
+		if err != nil {
+			return nil, err
+		}
+		return func(m protoreflect.Message) bool { return !expr(m) }, nil
+	case *pathNode:
+		fields, err := c.resolve(n)
+		if err != nil {
+			return nil, err
+		}
+		field := fields[len(fields)-1]
+		if field.Kind() != protoreflect.BoolKind {
+			return nil, c.errorf(n, "field %s is a %s, compare it with a value", n, typeName(field))
+		}
+		return anyField(fields, func(v protoreflect.Value) bool { return v.Bool() }), nil
+	case *compareNode:
+		fields, err := c.resolve(n.path)
+		if err != nil {
+			return nil, err
+		}
+		match, err := c.compare(n.path, fields[len(fields)-1], n.op, n.value)
+		if err != nil {
+			return nil, err
+		}
+		return anyField(fields, match), nil
+	case *inNode:
+		fields, err := c.resolve(n.path)
+		if err != nil {
+			return nil, err
+		}
+		eq := token{kind: tokenEq, text: "==", offset: n.path.offset()}
+		matches := make([]predicate, len(n.values))
+		for i, value := range n.values {
+			matches[i], err = c.compare(n.path, fields[len(fields)-1], eq, value)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return anyField(fields, func(v protoreflect.Value) bool {
+			for _, match := range matches {
+				if match(v) {
+					return true
+				}
+			}
+			return false
+		}), nil
+	default:
+		return nil, c.errorf(n, "unsupported expression")
+	}
+}
+
+// resolve returns the fields of a path, the last one can be compared with a literal
+func (c *compiler) resolve(path *pathNode) ([]protoreflect.FieldDescriptor, error) {
+	var fields []protoreflect.FieldDescriptor
+	message := c.descriptor
+	for i, name := range path.names {
+		if message == nil {
+			previous := fields[i-1]
+			return nil, newError(c.source, name.offset, "field %s of %s has no sub fields", previous.Name(), previous.ContainingMessage().FullName())
+		}
+
+		field := message.Fields().ByName(protoreflect.Name(name.text))
+		if field == nil {
+			return nil, newError(c.source, name.offset, "unknown field %s in %s", name.text, message.FullName())
+		}
+		if field.IsMap() {
+			return nil, newError(c.source, name.offset, "map field %s cannot be searched", name.text)
+		}
+		fields = append(fields, field)
+
+		message = nil
+		if field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind {
+			message = field.Message()
+		}
+	}
+	return fields, nil
+}
+
+// compare returns the predicate of a comparison of field with a value
+func (c *compiler) compare(path *pathNode, field protoreflect.FieldDescriptor, op token, value *valueNode) (predicate, error) {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		if op.kind != tokenEq && op.kind != tokenNe {
+			return nil, c.invalidOperator(path, field, op)
+		}
+		if value.tok.kind != tokenIdent || (value.tok.text != "true" && value.tok.text != "false") {
+			return nil, c.mismatch(path, field, value)
+		}
+		want := (value.tok.text == "true") == (op.kind == tokenEq)
+		return func(v protoreflect.Value) bool { return v.Bool() == want }, nil
+
+	case protoreflect.StringKind:
+		if op.kind != tokenEq && op.kind != tokenNe {
+			return nil, c.invalidOperator(path, field, op)
+		}
+		if value.tok.kind != tokenString {
+			return nil, c.mismatch(path, field, value)
+		}
+		want, eq := value.tok.str, op.kind == tokenEq
+		if c.foldCase[field.FullName()] {
+			return func(v protoreflect.Value) bool { return strings.EqualFold(v.String(), want) == eq }, nil
+		}
+		return func(v protoreflect.Value) bool { return (v.String() == want) == eq }, nil
+
+	case protoreflect.EnumKind:
+		if op.kind != tokenEq && op.kind != tokenNe {
+			return nil, c.invalidOperator(path, field, op)
+		}
+		if value.tok.kind != tokenIdent && value.tok.kind != tokenString {
+			return nil, c.mismatch(path, field, value)
+		}
+		name := value.tok.text
+		if value.tok.kind == tokenString {
+			name = value.tok.str
+		}
+		enumValue := field.Enum().Values().ByName(protoreflect.Name(name))
+		if enumValue == nil {
+			return nil, c.errorf(value, "%s is not a value of enum %s", name, field.Enum().FullName())
+		}
+		want, eq := enumValue.Number(), op.kind == tokenEq
+		return func(v protoreflect.Value) bool { return (v.Enum() == want) == eq }, nil
+
+	case protoreflect.MessageKind:
+		if !c.isMemory(field) {
+			return nil, c.errorf(path, "field %s is a %s message and cannot be compared", path, field.Message().FullName())
+		}
+		if value.tok.kind != tokenMemory {
+			return nil, c.errorf(value, "field %s is a memory size, compare it with a value such as 16GB", path)
+		}
+		want := value.tok.bits
+		return func(v protoreflect.Value) bool {
+			return compareOrder(op.kind, compareUint(memoryBits(v.Message()), want))
+		}, nil
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		want, err := c.number(path, field, value)
+		if err != nil {
+			return nil, err
+		}
+		return func(v protoreflect.Value) bool {
+			return compareOrder(op.kind, compareFloat(float64(v.Int()), want))
+		}, nil
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		want, err := c.number(path, field, value)
+		if err != nil {
+			return nil, err
+		}
+		return func(v protoreflect.Value) bool {
+			return compareOrder(op.kind, compareFloat(float64(v.Uint()), want))
+		}, nil
+
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		want, err := c.number(path, field, value)
+		if err != nil {
+			return nil, err
+		}
+		return func(v protoreflect.Value) bool {
+			return compareOrder(op.kind, compareFloat(v.Float(), want))
+		}, nil
+
+	default:
+		return nil, c.errorf(path, "field %s is a %s and cannot be searched", path, typeName(field))
+	}
+}
+
+// isMemory reports whether the values of field are memory messages
+func (c *compiler) isMemory(field protoreflect.FieldDescriptor) bool {
+	return c.memory != nil && field.Kind() == protoreflect.MessageKind && field.Message().FullName() == c.memory.FullName()
+}
+
+func (c *compiler) number(path *pathNode, field protoreflect.FieldDescriptor, value *valueNode) (float64, error) {
+	if value.tok.kind != tokenNumber {
+		return 0, c.mismatch(path, field, value)
+	}
+	if value.negative {
+		return -value.tok.number, nil
+	}
+	return value.tok.number, nil
+}
+
+func (c *compiler) mismatch(path *pathNode, field protoreflect.FieldDescriptor, value *valueNode) error {
+	return c.errorf(value, "cannot compare %s field %s with %s", typeName(field), path, value.tok)
+}
+
+func (c *compiler) invalidOperator(path *pathNode, field protoreflect.FieldDescriptor, op token) error {
+	return newError(c.source, op.offset, "operator %s is not defined on %s field %s", op.kind, typeName(field), path)
+}
+
+func (c *compiler) errorf(n node, format string, args ...interface{}) error {
+	return newError(c.source, n.offset(), format, args...)
+}
+
+// anyField returns an evaluator that is true when match is true for a value of the field path,
+// a path through repeated fields has a value for each element
+func anyField(fields []protoreflect.FieldDescriptor, match predicate) evaluator {
+	return func(m protoreflect.Message) bool {
+		return anyValue(m, fields, match)
+	}
+}
+
+func anyValue(m protoreflect.Message, fields []protoreflect.FieldDescriptor, match predicate) bool {
+	field := fields[0]
+	if field.HasPresence() && !m.Has(field) {
+		return false
+	}
+
+	value := m.Get(field)
+	if !field.IsList() {
+		return anyElement(value, fields[1:], match)
+	}
+
+	list := value.List()
+	for i := 0; i < list.Len(); i++ {
+		if anyElement(list.Get(i), fields[1:], match) {
+			return true
+		}
+	}
+	return false
+}
+
+func anyElement(value protoreflect.Value, rest []protoreflect.FieldDescriptor, match predicate) bool {
+	if len(rest) == 0 {
+		return match(value)
+	}
+	return anyValue(value.Message(), rest, match)
+}
+
+// memoryBits returns the size in bits of a memory message
+func memoryBits(m protoreflect.Message) uint64 {
+	fields := m.Descriptor().Fields()
+	value := m.Get(fields.ByName("value")).Uint()
+	unitField := fields.ByName("unit")
+	unit := unitField.Enum().Values().ByNumber(m.Get(unitField).Enum())
+	if unit == nil {
+		return 0
+	}
+	return value * unitBits[unit.Name()]
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareOrder reports whether the result of a comparison satisfies op
+func compareOrder(op tokenKind, result int) bool {
+	switch op {
+	case tokenEq:
+		return result == 0
+	case tokenNe:
+		return result != 0
+	case tokenLt:
+		return result < 0
+	case tokenLe:
+		return result <= 0
+	case tokenGt:
+		return result > 0
+	case tokenGe:
+		return result >= 0
+	default:
+		return false
+	}
+}
+
+// typeName returns the name of the type of a field in error messages
+func typeName(field protoreflect.FieldDescriptor) string {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return string(field.Message().Name())
+	case protoreflect.EnumKind:
+		return "enum"
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BoolKind:
+		return "bool"
+	case protoreflect.BytesKind:
+		return "bytes"
+	default:
+		return "number"
+	}
+}
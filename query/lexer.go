@@ -0,0 +1,258 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenMemory
+	tokenString
+	tokenIn
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenEq
+	tokenNe
+	tokenLt
+	tokenLe
+	tokenGt
+	tokenGe
+	tokenMinus
+	tokenDot
+	tokenComma
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+)
+
+var tokenNames = map[tokenKind]string{
+	tokenEOF:      "end of query",
+	tokenIdent:    "identifier",
+	tokenNumber:   "number",
+	tokenMemory:   "memory size",
+	tokenString:   "string",
+	tokenIn:       "'in'",
+	tokenAnd:      "'&&'",
+	tokenOr:       "'||'",
+	tokenNot:      "'!'",
+	tokenEq:       "'=='",
+	tokenNe:       "'!='",
+	tokenLt:       "'<'",
+	tokenLe:       "'<='",
+	tokenGt:       "'>'",
+	tokenGe:       "'>='",
+	tokenMinus:    "'-'",
+	tokenDot:      "'.'",
+	tokenComma:    "','",
+	tokenLParen:   "'('",
+	tokenRParen:   "')'",
+	tokenLBracket: "'['",
+	tokenRBracket: "']'",
+}
+
+func (kind tokenKind) String() string {
+	return tokenNames[kind]
+}
+
+// token is a lexical element of a query, offset is its byte position in the source
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+
+	number float64 // value of a number token
+	bits   uint64  // value of a memory token
+	str    string  // unquoted value of a string token
+}
+
+func (tok token) String() string {
+	switch tok.kind {
+	case tokenIdent, tokenNumber, tokenMemory, tokenString:
+		return fmt.Sprintf("%s %s", tok.kind, tok.text)
+	default:
+		return tok.kind.String()
+	}
+}
+
+// memoryUnits is the size in bits of the units of memory literals,
+// the multiples are binary like the units of the memory messages
+var memoryUnits = map[string]uint64{
+	"bit": 1,
+	"b":   1,
+	"B":   8,
+	"KB":  8 << 10,
+	"MB":  8 << 20,
+	"GB":  8 << 30,
+	"TB":  8 << 40,
+}
+
+var operators = []struct {
+	text string
+	kind tokenKind
+}{
+	{"&&", tokenAnd},
+	{"||", tokenOr},
+	{"==", tokenEq},
+	{"!=", tokenNe},
+	{"<=", tokenLe},
+	{">=", tokenGe},
+	{"!", tokenNot},
+	{"<", tokenLt},
+	{">", tokenGt},
+	{"-", tokenMinus},
+	{".", tokenDot},
+	{",", tokenComma},
+	{"(", tokenLParen},
+	{")", tokenRParen},
+	{"[", tokenLBracket},
+	{"]", tokenRBracket},
+}
+
+// lex splits the source of a query into tokens, the last one is always tokenEOF
+func lex(source string) ([]token, error) {
+	var tokens []token
+	offset := 0
+	for {
+		for offset < len(source) {
+			r, size := utf8.DecodeRuneInString(source[offset:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			offset += size
+		}
+		if offset == len(source) {
+			tokens = append(tokens, token{kind: tokenEOF, offset: offset})
+			return tokens, nil
+		}
+
+		tok, err := lexToken(source, offset)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+		offset += len(tok.text)
+	}
+}
+
+func lexToken(source string, offset int) (token, error) {
+	rest := source[offset:]
+	r, _ := utf8.DecodeRuneInString(rest)
+
+	switch {
+	case isIdentStart(r):
+		end := identEnd(rest)
+		tok := token{kind: tokenIdent, text: rest[:end], offset: offset}
+		if tok.text == "in" {
+			tok.kind = tokenIn
+		}
+		return tok, nil
+	case r >= '0' && r <= '9':
+		return lexNumber(source, offset)
+	case r == '"':
+		return lexString(source, offset)
+	}
+
+	for _, op := range operators {
+		if strings.HasPrefix(rest, op.text) {
+			return token{kind: op.kind, text: op.text, offset: offset}, nil
+		}
+	}
+
+	switch r {
+	case '=':
+		return token{}, newError(source, offset, "unexpected '=', use '==' to compare")
+	case '&':
+		return token{}, newError(source, offset, "unexpected '&', use '&&'")
+	case '|':
+		return token{}, newError(source, offset, "unexpected '|', use '||'")
+	default:
+		return token{}, newError(source, offset, "unexpected character %q", r)
+	}
+}
+
+// lexNumber reads a number, and its unit if it is a memory size such as 16GB
+func lexNumber(source string, offset int) (token, error) {
+	rest := source[offset:]
+	end := digitsEnd(rest, 0)
+	if end+1 < len(rest) && rest[end] == '.' && rest[end+1] >= '0' && rest[end+1] <= '9' {
+		end = digitsEnd(rest, end+1)
+	}
+
+	number, err := strconv.ParseFloat(rest[:end], 64)
+	if err != nil {
+		return token{}, newError(source, offset, "invalid number %s", rest[:end])
+	}
+
+	unitEnd := identEnd(rest[end:])
+	if unitEnd == 0 {
+		return token{kind: tokenNumber, text: rest[:end], offset: offset, number: number}, nil
+	}
+
+	unit := rest[end : end+unitEnd]
+	bits, ok := memoryUnits[unit]
+	if !ok && len(unit) == 2 {
+		// 16gb or 16Gb reads as 16GB
+		bits, ok = memoryUnits[strings.ToUpper(unit)]
+	}
+	if !ok {
+		return token{}, newError(source, offset+end, "unknown memory unit %q", unit)
+	}
+
+	return token{
+		kind:   tokenMemory,
+		text:   rest[:end+unitEnd],
+		offset: offset,
+		bits:   uint64(number * float64(bits)),
+	}, nil
+}
+
+// lexString reads a double quoted string with Go escape sequences
+func lexString(source string, offset int) (token, error) {
+	rest := source[offset:]
+	for i := 1; i < len(rest); i++ {
+		switch rest[i] {
+		case '\\':
+			i++
+		case '"':
+			text := rest[:i+1]
+			str, err := strconv.Unquote(text)
+			if err != nil {
+				return token{}, newError(source, offset, "invalid string %s", text)
+			}
+			return token{kind: tokenString, text: text, offset: offset, str: str}, nil
+		}
+	}
+	return token{}, newError(source, offset, "unterminated string")
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func identEnd(s string) int {
+	for i, r := range s {
+		if !isIdentStart(r) && !unicode.IsDigit(r) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+func digitsEnd(s string, start int) int {
+	for i := start; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return i
+		}
+	}
+	return len(s)
+}
@@ -0,0 +1,283 @@
+package query
+
+// maxDepth limits the nesting of parentheses and negations
+const maxDepth = 100
+
+// node is an expression of the syntax tree of a query
+type node interface {
+	offset() int
+}
+
+// binaryNode is a && or || expression
+type binaryNode struct {
+	op          token
+	left, right node
+}
+
+// notNode is a negated expression
+type notNode struct {
+	op   token
+	expr node
+}
+
+// compareNode compares a field with a value
+type compareNode struct {
+	path  *pathNode
+	op    token
+	value *valueNode
+}
+
+// inNode checks that a field is equal to one of a list of values
+type inNode struct {
+	path   *pathNode
+	values []*valueNode
+}
+
+// pathNode is a dotted field path such as cpu.num_cores,
+// as an expression it is true when the bool field is set
+type pathNode struct {
+	names []token
+}
+
+// valueNode is a literal, an identifier value is an enum value name or a bool
+type valueNode struct {
+	tok      token
+	negative bool
+}
+
+func (n *binaryNode) offset() int  { return n.left.offset() }
+func (n *notNode) offset() int     { return n.op.offset }
+func (n *compareNode) offset() int { return n.path.offset() }
+func (n *inNode) offset() int      { return n.path.offset() }
+func (n *pathNode) offset() int    { return n.names[0].offset }
+func (n *valueNode) offset() int   { return n.tok.offset }
+
+// String returns the dotted form of the path
+func (n *pathNode) String() string {
+	s := ""
+	for i, name := range n.names {
+		if i > 0 {
+			s += "."
+		}
+		s += name.text
+	}
+	return s
+}
+
+type parser struct {
+	source string
+	tokens []token
+	pos    int
+	depth  int
+}
+
+// parse returns the syntax tree of a query
+//
+//	expr       = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | primary
+//	primary    = "(" expr ")" | path [ compare | "in" list ]
+//	compare    = ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) value
+//	list       = "[" value { "," value } "]"
+//	path       = ident { "." ident }
+//	value      = [ "-" ] number | memory | string | ident
+func parse(source string) (node, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{source: source, tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.unexpected(tok, "'&&', '||' or end of query")
+	}
+	return expr, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) expect(kind tokenKind) (token, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return tok, p.unexpected(tok, kind.String())
+	}
+	return tok, nil
+}
+
+func (p *parser) unexpected(tok token, expected string) error {
+	return newError(p.source, tok.offset, "expected %s, found %s", expected, tok)
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		op := p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		op := p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.peek().kind != tokenNot {
+		return p.parsePrimary()
+	}
+
+	op := p.next()
+	if p.depth++; p.depth > maxDepth {
+		return nil, newError(p.source, op.offset, "query is nested too deeply")
+	}
+	expr, err := p.parseUnary()
+	p.depth--
+	if err != nil {
+		return nil, err
+	}
+	return &notNode{op: op, expr: expr}, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokenLParen:
+		p.next()
+		if p.depth++; p.depth > maxDepth {
+			return nil, newError(p.source, tok.offset, "query is nested too deeply")
+		}
+		expr, err := p.parseOr()
+		p.depth--
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.expect(tokenRParen)
+		if err != nil {
+			return nil, err
+		}
+		return expr, nil
+	case tokenIdent:
+		return p.parseCondition()
+	default:
+		return nil, p.unexpected(tok, "field name, '!' or '('")
+	}
+}
+
+func (p *parser) parseCondition() (node, error) {
+	path, err := p.parsePath()
+	if err != nil {
+		return nil, err
+	}
+
+	switch p.peek().kind {
+	case tokenEq, tokenNe, tokenLt, tokenLe, tokenGt, tokenGe:
+		op := p.next()
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return &compareNode{path: path, op: op, value: value}, nil
+	case tokenIn:
+		p.next()
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return &inNode{path: path, values: values}, nil
+	default:
+		return path, nil
+	}
+}
+
+func (p *parser) parsePath() (*pathNode, error) {
+	name, err := p.expect(tokenIdent)
+	if err != nil {
+		return nil, err
+	}
+
+	path := &pathNode{names: []token{name}}
+	for p.peek().kind == tokenDot {
+		p.next()
+		name, err = p.expect(tokenIdent)
+		if err != nil {
+			return nil, err
+		}
+		path.names = append(path.names, name)
+	}
+	return path, nil
+}
+
+func (p *parser) parseList() ([]*valueNode, error) {
+	_, err := p.expect(tokenLBracket)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []*valueNode
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		tok := p.next()
+		switch tok.kind {
+		case tokenComma:
+			continue
+		case tokenRBracket:
+			return values, nil
+		default:
+			return nil, p.unexpected(tok, "',' or ']'")
+		}
+	}
+}
+
+func (p *parser) parseValue() (*valueNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenNumber, tokenMemory, tokenString, tokenIdent:
+		return &valueNode{tok: tok}, nil
+	case tokenMinus:
+		number, err := p.expect(tokenNumber)
+		if err != nil {
+			return nil, err
+		}
+		return &valueNode{tok: number, negative: true}, nil
+	default:
+		return nil, p.unexpected(tok, "value")
+	}
+}
@@ -0,0 +1,67 @@
+package query
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Path is a dotted field path checked against a message descriptor, such as cpu.brand
+type Path struct {
+	source string
+	fields []protoreflect.FieldDescriptor
+	memory bool
+}
+
+// ParsePath parses a dotted field path of the messages of descriptor,
+// the memory messages are set by the WithMemory option
+func ParsePath(descriptor protoreflect.MessageDescriptor, source string, options ...Option) (*Path, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{source: source, tokens: tokens}
+	path, err := p.parsePath()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.unexpected(tok, "'.' or end of path")
+	}
+
+	c := newCompiler(source, descriptor, options)
+	fields, err := c.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Path{source: source, fields: fields, memory: c.isMemory(fields[len(fields)-1])}, nil
+}
+
+// Field returns the descriptor of the last field of the path
+func (path *Path) Field() protoreflect.FieldDescriptor {
+	return path.fields[len(path.fields)-1]
+}
+
+// Range calls f with each value of the path in message,
+// a path through repeated fields has a value for each element
+func (path *Path) Range(message proto.Message, f func(value protoreflect.Value)) {
+	anyValue(message.ProtoReflect(), path.fields, func(value protoreflect.Value) bool {
+		f(value)
+		return false
+	})
+}
+
+// String returns the source of the path
+func (path *Path) String() string {
+	return path.source
+}
+
+// IsMemory reports whether the path names a memory size, its values are the memory messages of the WithMemory option
+func (path *Path) IsMemory() bool {
+	return path.memory
+}
+
+// MemoryBits returns the size in bits of a memory value of the path
+func MemoryBits(value protoreflect.Value) uint64 {
+	return memoryBits(value.Message())
+}
@@ -0,0 +1,96 @@
+// Package query implements a small expression language to search protobuf messages,
+// for example:
+//
+//	cpu.num_cores >= 8 && ram >= 16GB && brand in ["Dell", "Lenovo"] && price_usd < 2500
+//
+// A query compares the fields of a message with literals, and combines the comparisons
+// with &&, || and !. Fields are named by their dotted protobuf path and checked against
+// the message descriptor when the query is compiled. A path through a repeated field,
+// such as gpus.brand, matches when any element matches.
+package query
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+)
+
+// Error is a syntax or type error of a query
+type Error struct {
+	// Offset is the byte position of the error in the query
+	Offset int
+	// Line and Column are the 1-based position of the error
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+func newError(source string, offset int, format string, args ...interface{}) error {
+	line := 1 + strings.Count(source[:offset], "\n")
+	lineStart := strings.LastIndex(source[:offset], "\n") + 1
+	return &Error{
+		Offset: offset,
+		Line:   line,
+		Column: 1 + len([]rune(source[lineStart:offset])),
+		Msg:    fmt.Sprintf(format, args...),
+	}
+}
+
+// Query is a compiled query over the messages of one descriptor
+type Query struct {
+	source     string
+	descriptor protoreflect.MessageDescriptor
+	match      evaluator
+}
+
+// Compile parses the source of a query and type checks it against descriptor
+func Compile(descriptor protoreflect.MessageDescriptor, source string, options ...Option) (*Query, error) {
+	expr, err := parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newCompiler(source, descriptor, options)
+	match, err := c.compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{
+		source:     source,
+		descriptor: descriptor,
+		match:      match,
+	}, nil
+}
+
+func newCompiler(source string, descriptor protoreflect.MessageDescriptor, options []Option) *compiler {
+	c := &compiler{
+		source:     source,
+		descriptor: descriptor,
+		foldCase:   make(map[protoreflect.FullName]bool),
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Match reports whether message matches the query,
+// a message of another type never matches
+func (q *Query) Match(message proto.Message) bool {
+	m := message.ProtoReflect()
+	if m.Descriptor().FullName() != q.descriptor.FullName() {
+		return false
+	}
+	return q.match(m)
+}
+
+// String returns the source of the query
+func (q *Query) String() string {
+	return q.source
+}
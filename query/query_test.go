@@ -0,0 +1,160 @@
+package query
+
+import (
+	"github.com/stretchr/testify/require"
+	"library/v1/pb"
+	"testing"
+)
+
+func newTestLaptop() *pb.Laptop {
+	return &pb.Laptop{
+		Id:    "laptop-1",
+		Brand: "Dell",
+		Name:  "XPS",
+		Cpu: &pb.CPU{
+			Brand:      "Intel",
+			NumCores:   8,
+			NumThreads: 16,
+			MinGhz:     2.5,
+			MaxGhz:     4.5,
+		},
+		Ram: &pb.Memory{Value: 16, Unit: pb.Memory_GIGABYTE},
+		Gpus: []*pb.GPU{
+			{Brand: "Intel", Name: "Iris"},
+			{Brand: "NVIDIA", Name: "RTX 3060", Memory: &pb.Memory{Value: 6, Unit: pb.Memory_GIGABYTE}},
+		},
+		Screen: &pb.Screen{
+			SizeInch: 15.6,
+			Panel:    pb.Screen_OLED,
+		},
+		Keyboard: &pb.Keyboard{Backlit: true},
+		Weight:   &pb.Laptop_WeightKg{WeightKg: 1.8},
+		PriceUsd: 2100,
+	}
+}
+
+// testOptions compare the memory messages with memory sizes and the brands without case
+var testOptions = []Option{
+	WithMemory((&pb.Memory{}).ProtoReflect().Descriptor()),
+	WithFoldCase("techschool.proto.Laptop.brand", "techschool.proto.GPU.brand"),
+}
+
+func TestQueryMatch(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		query string
+		match bool
+	}
+
+	testCases := []testCase{
+		{`cpu.num_cores >= 8 && ram >= 16GB && brand in ["Dell","Lenovo"] && price_usd < 2500`, true},
+		{`cpu.num_cores > 8`, false},
+		{`ram > 16GB`, false},
+		{`ram == 16384MB`, true},
+		{`ram >= 0.5TB`, false},
+		{`ram < 17gb`, true},
+		{`brand == "Lenovo" || price_usd <= 2100`, true},
+		{`!(brand == "Dell")`, false},
+		{`brand != "Dell" && price_usd > 0`, false},
+		{`gpus.brand == "NVIDIA"`, true},
+		{`gpus.memory >= 8GB`, false},
+		{`gpus.memory >= 6GB`, true},
+		{`screen.panel == OLED`, true},
+		{`screen.panel in ["IPS"]`, false},
+		{`keyboard.backlit`, true},
+		{`keyboard.backlit == false`, false},
+		{`weight_kg < 2 && !(weight_lb > 0)`, true},
+		{`weight_lb < 5`, false},
+		{`release_year == 0 && price_usd > -1`, true},
+		{`ram.value == 16`, true},
+		{`brand == "dell"`, true},
+		{`brand in ["LENOVO", "DELL"]`, true},
+		{`brand != "dELL"`, false},
+		{`gpus.brand == "nvidia"`, true},
+		{`cpu.brand == "intel"`, false},
+		{`name == "xps"`, false},
+	}
+
+	laptop := newTestLaptop()
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.query, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := Compile(laptop.ProtoReflect().Descriptor(), tc.query, testOptions...)
+			require.NoError(t, err)
+			require.Equal(t, tc.match, q.Match(laptop))
+		})
+	}
+}
+
+func TestQueryError(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		query  string
+		line   int
+		column int
+		msg    string
+	}
+
+	testCases := []testCase{
+		{`price_usd <`, 1, 12, "expected value, found end of query"},
+		{`price_usd = 1`, 1, 11, "unexpected '=', use '==' to compare"},
+		{`(price_usd > 1`, 1, 15, "expected ')', found end of query"},
+		{`price_usd > 1 price_usd`, 1, 15, "expected '&&', '||' or end of query, found identifier price_usd"},
+		{`brand == "Dell`, 1, 10, "unterminated string"},
+		{`color == "red"`, 1, 1, "unknown field color in techschool.proto.Laptop"},
+		{`cpu.speed > 2`, 1, 5, "unknown field speed in techschool.proto.CPU"},
+		{`brand.name == "x"`, 1, 7, "field brand of techschool.proto.Laptop has no sub fields"},
+		{`ram >= 16`, 1, 8, "field ram is a memory size, compare it with a value such as 16GB"},
+		{`ram >= 16XB`, 1, 10, `unknown memory unit "XB"`},
+		{`price_usd < "cheap"`, 1, 13, `cannot compare number field price_usd with string "cheap"`},
+		{`brand < "Dell"`, 1, 7, "operator '<' is not defined on string field brand"},
+		{`screen.panel == AMOLED`, 1, 17, "AMOLED is not a value of enum techschool.proto.Screen.Panel"},
+		{`cpu > 1`, 1, 1, "field cpu is a techschool.proto.CPU message and cannot be compared"},
+		{`price_usd`, 1, 1, "field price_usd is a number, compare it with a value"},
+		{"price_usd > 1 &&\n  brand == 1", 2, 12, "cannot compare string field brand with number 1"},
+	}
+
+	descriptor := (&pb.Laptop{}).ProtoReflect().Descriptor()
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.query, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Compile(descriptor, tc.query, testOptions...)
+			require.Error(t, err)
+
+			queryErr, ok := err.(*Error)
+			require.True(t, ok)
+			require.Equal(t, tc.line, queryErr.Line)
+			require.Equal(t, tc.column, queryErr.Column)
+			require.Equal(t, tc.msg, queryErr.Msg)
+		})
+	}
+}
+
+// TestQueryWithoutOptions test the memory messages and the brands are compared like the other fields without options
+func TestQueryWithoutOptions(t *testing.T) {
+	t.Parallel()
+
+	laptop := newTestLaptop()
+	descriptor := laptop.ProtoReflect().Descriptor()
+
+	_, err := Compile(descriptor, `ram >= 16GB`)
+	require.Error(t, err)
+	require.Equal(t, "field ram is a techschool.proto.Memory message and cannot be compared", err.(*Error).Msg)
+
+	q, err := Compile(descriptor, `brand == "dell"`)
+	require.NoError(t, err)
+	require.False(t, q.Match(laptop))
+
+	path, err := ParsePath(descriptor, "ram")
+	require.NoError(t, err)
+	require.False(t, path.IsMemory())
+	path, err = ParsePath(descriptor, "ram", testOptions...)
+	require.NoError(t, err)
+	require.True(t, path.IsMemory())
+}
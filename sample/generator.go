@@ -22,10 +22,10 @@ func NewCPU() *pb.CPU {
 	name := randomCPUName(brand)
 
 	numcores := randomInt(2, 8)
-	numthreads := randomInt(4, 32)
+	numthreads := randomInt(numcores, 12)
 
-	minGhz := randomFloat64(2.0, 5.0)
-	maxGhz := randomFloat64(2.0, 5.0)
+	minGhz := randomFloat64(2.0, 3.5)
+	maxGhz := randomFloat64(minGhz, 5.0)
 
 	cpu := &pb.CPU{
 		Brand:      brand,
@@ -47,8 +47,8 @@ func NewGPU() *pb.GPU {
 	// cpu
 	brand := randomGPUBrand()
 	name := randomGPUName(brand)
-	minGhz := randomFloat64(2.0, 5.0)
-	maxGhz := randomFloat64(2.0, 5.0)
+	minGhz := randomFloat64(1.0, 1.5)
+	maxGhz := randomFloat64(minGhz, 2.0)
 	gpu := &pb.GPU{
 		Brand:  brand,
 		Name:   name,
@@ -0,0 +1,638 @@
+package serializer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"library/v1/pb"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The CSV mapping of a laptop has one column per field, nested fields are named by their path such as
+// cpu.num_cores. A GPU or a storage has a group of columns per position: gpu1.name, gpu2.name, storage1.memory...
+// Memory sizes are written with their unit such as 16GB and the screen resolution as 1920x1080.
+// An empty cell leaves the field unset, the message fields of a laptop are only set if one of their cells is not empty.
+
+// CSVColumns is the number of GPUs and storages a CSV file has columns for
+type CSVColumns struct {
+	GPUs     int
+	Storages int
+}
+
+// CSVColumnsOf returns the columns needed to write every laptop
+func CSVColumnsOf(laptops []*pb.Laptop) CSVColumns {
+	columns := CSVColumns{}
+	for _, laptop := range laptops {
+		if len(laptop.GetGpus()) > columns.GPUs {
+			columns.GPUs = len(laptop.GetGpus())
+		}
+		if len(laptop.GetStorages()) > columns.Storages {
+			columns.Storages = len(laptop.GetStorages())
+		}
+	}
+	return columns
+}
+
+// csvColumn reads and writes a cell of a laptop row,
+// set is only called for a cell that is not empty
+type csvColumn struct {
+	name string
+	get  func(laptop *pb.Laptop) string
+	set  func(laptop *pb.Laptop, value string) error
+}
+
+// CSVEncoder writes laptops as the rows of a CSV file, the header is written before the first row
+type CSVEncoder struct {
+	writer  *csv.Writer
+	columns CSVColumns
+	header  []csvColumn
+	wrote   bool
+}
+
+// NewCSVEncoder returns an encoder with the GPU and storage columns of columns
+func NewCSVEncoder(writer io.Writer, columns CSVColumns) *CSVEncoder {
+	return &CSVEncoder{
+		writer:  csv.NewWriter(writer),
+		columns: columns,
+		header:  laptopColumns(columns),
+	}
+}
+
+// Encode writes the row of a laptop, it fails if the laptop has more GPUs or storages than the columns
+func (encoder *CSVEncoder) Encode(laptop *pb.Laptop) error {
+	if len(laptop.GetGpus()) > encoder.columns.GPUs {
+		return fmt.Errorf("laptop %s has %d GPUs, the CSV file has columns for %d",
+			laptop.GetId(), len(laptop.GetGpus()), encoder.columns.GPUs)
+	}
+	if len(laptop.GetStorages()) > encoder.columns.Storages {
+		return fmt.Errorf("laptop %s has %d storages, the CSV file has columns for %d",
+			laptop.GetId(), len(laptop.GetStorages()), encoder.columns.Storages)
+	}
+
+	if !encoder.wrote {
+		names := make([]string, len(encoder.header))
+		for i, column := range encoder.header {
+			names[i] = column.name
+		}
+		err := encoder.writer.Write(names)
+		if err != nil {
+			return err
+		}
+		encoder.wrote = true
+	}
+
+	row := make([]string, len(encoder.header))
+	for i, column := range encoder.header {
+		row[i] = column.get(laptop)
+	}
+	return encoder.writer.Write(row)
+}
+
+// Flush writes the buffered rows
+func (encoder *CSVEncoder) Flush() error {
+	encoder.writer.Flush()
+	return encoder.writer.Error()
+}
+
+// CSVDecoder reads laptops from the rows of a CSV file,
+// the columns can be in any order and the missing ones leave their field unset
+type CSVDecoder struct {
+	reader *csv.Reader
+	header []csvColumn
+	row    int // number of rows read, with the header
+}
+
+// NewCSVDecoder returns a decoder of the CSV file of reader
+func NewCSVDecoder(reader io.Reader) *CSVDecoder {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	return &CSVDecoder{reader: csvReader}
+}
+
+// Decode reads the laptop of the next row, it returns io.EOF after the last row.
+// A row with a cell that cannot be parsed returns ErrInvalidMessage, the next rows can still be decoded.
+func (decoder *CSVDecoder) Decode(laptop *pb.Laptop) error {
+	if decoder.header == nil {
+		err := decoder.readHeader()
+		if err != nil {
+			return err
+		}
+	}
+
+	row, err := decoder.reader.Read()
+	if err == io.EOF {
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read CSV row: %w", err)
+	}
+	decoder.row++
+	if len(row) != len(decoder.header) {
+		return fmt.Errorf("row %d: %w: it has %d cells, the header has %d",
+			decoder.row, ErrInvalidMessage, len(row), len(decoder.header))
+	}
+
+	proto.Reset(laptop)
+	for i, column := range decoder.header {
+		value := strings.TrimSpace(row[i])
+		if value == "" {
+			continue
+		}
+		err := column.set(laptop, value)
+		if err != nil {
+			return fmt.Errorf("row %d: %w: column %s: %v", decoder.row, ErrInvalidMessage, column.name, err)
+		}
+	}
+	return nil
+}
+
+func (decoder *CSVDecoder) readHeader() error {
+	names, err := decoder.reader.Read()
+	if err == io.EOF {
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read CSV header: %w", err)
+	}
+
+	// the columns of the GPUs and storages of the header
+	columns := CSVColumns{}
+	for _, name := range names {
+		var n int
+		if _, err := fmt.Sscanf(name, "gpu%d.", &n); err == nil && n > columns.GPUs {
+			columns.GPUs = n
+		}
+		if _, err := fmt.Sscanf(name, "storage%d.", &n); err == nil && n > columns.Storages {
+			columns.Storages = n
+		}
+	}
+
+	known := make(map[string]csvColumn)
+	for _, column := range laptopColumns(columns) {
+		known[column.name] = column
+	}
+
+	header := make([]csvColumn, len(names))
+	for i, name := range names {
+		column, ok := known[strings.TrimSpace(name)]
+		if !ok {
+			return fmt.Errorf("unknown CSV column: %q", name)
+		}
+		header[i] = column
+	}
+	decoder.header = header
+	decoder.row = 1
+	return nil
+}
+
+// WriteLaptopsToCSV writes laptops to a CSV file with the columns of all their GPUs and storages
+func WriteLaptopsToCSV(writer io.Writer, laptops []*pb.Laptop) error {
+	encoder := NewCSVEncoder(writer, CSVColumnsOf(laptops))
+	for _, laptop := range laptops {
+		err := encoder.Encode(laptop)
+		if err != nil {
+			return err
+		}
+	}
+	return encoder.Flush()
+}
+
+// ReadLaptopsFromCSV reads every laptop of a CSV file
+func ReadLaptopsFromCSV(reader io.Reader) ([]*pb.Laptop, error) {
+	decoder := NewCSVDecoder(reader)
+	var laptops []*pb.Laptop
+	for {
+		laptop := &pb.Laptop{}
+		err := decoder.Decode(laptop)
+		if err == io.EOF {
+			return laptops, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		laptops = append(laptops, laptop)
+	}
+}
+
+// laptopColumns returns the columns of a laptop row in the order of the laptop fields
+func laptopColumns(columns CSVColumns) []csvColumn {
+	cpu := func(laptop *pb.Laptop) *pb.CPU {
+		if laptop.Cpu == nil {
+			laptop.Cpu = &pb.CPU{}
+		}
+		return laptop.Cpu
+	}
+	screen := func(laptop *pb.Laptop) *pb.Screen {
+		if laptop.Screen == nil {
+			laptop.Screen = &pb.Screen{}
+		}
+		return laptop.Screen
+	}
+	keyboard := func(laptop *pb.Laptop) *pb.Keyboard {
+		if laptop.Keyboard == nil {
+			laptop.Keyboard = &pb.Keyboard{}
+		}
+		return laptop.Keyboard
+	}
+
+	result := []csvColumn{
+		{
+			name: "id",
+			get:  func(laptop *pb.Laptop) string { return laptop.GetId() },
+			set:  func(laptop *pb.Laptop, value string) error { laptop.Id = value; return nil },
+		},
+		{
+			name: "brand",
+			get:  func(laptop *pb.Laptop) string { return laptop.GetBrand() },
+			set:  func(laptop *pb.Laptop, value string) error { laptop.Brand = value; return nil },
+		},
+		{
+			name: "name",
+			get:  func(laptop *pb.Laptop) string { return laptop.GetName() },
+			set:  func(laptop *pb.Laptop, value string) error { laptop.Name = value; return nil },
+		},
+		{
+			name: "cpu.brand",
+			get:  func(laptop *pb.Laptop) string { return ifSet(laptop.Cpu != nil, laptop.GetCpu().GetBrand()) },
+			set:  func(laptop *pb.Laptop, value string) error { cpu(laptop).Brand = value; return nil },
+		},
+		{
+			name: "cpu.name",
+			get:  func(laptop *pb.Laptop) string { return ifSet(laptop.Cpu != nil, laptop.GetCpu().GetName()) },
+			set:  func(laptop *pb.Laptop, value string) error { cpu(laptop).Name = value; return nil },
+		},
+		{
+			name: "cpu.num_cores",
+			get: func(laptop *pb.Laptop) string {
+				return ifSet(laptop.Cpu != nil, formatUint(laptop.GetCpu().GetNumCores()))
+			},
+			set: func(laptop *pb.Laptop, value string) error {
+				return parseUint(value, &cpu(laptop).NumCores)
+			},
+		},
+		{
+			name: "cpu.num_threads",
+			get: func(laptop *pb.Laptop) string {
+				return ifSet(laptop.Cpu != nil, formatUint(laptop.GetCpu().GetNumThreads()))
+			},
+			set: func(laptop *pb.Laptop, value string) error {
+				return parseUint(value, &cpu(laptop).NumThreads)
+			},
+		},
+		{
+			name: "cpu.min_ghz",
+			get: func(laptop *pb.Laptop) string {
+				return ifSet(laptop.Cpu != nil, formatFloat(laptop.GetCpu().GetMinGhz()))
+			},
+			set: func(laptop *pb.Laptop, value string) error {
+				return parseFloat(value, &cpu(laptop).MinGhz)
+			},
+		},
+		{
+			name: "cpu.max_ghz",
+			get: func(laptop *pb.Laptop) string {
+				return ifSet(laptop.Cpu != nil, formatFloat(laptop.GetCpu().GetMaxGhz()))
+			},
+			set: func(laptop *pb.Laptop, value string) error {
+				return parseFloat(value, &cpu(laptop).MaxGhz)
+			},
+		},
+		{
+			name: "ram",
+			get:  func(laptop *pb.Laptop) string { return FormatMemory(laptop.GetRam()) },
+			set: func(laptop *pb.Laptop, value string) error {
+				var err error
+				laptop.Ram, err = ParseMemory(value)
+				return err
+			},
+		},
+	}
+
+	for i := 0; i < columns.GPUs; i++ {
+		result = append(result, gpuColumns(i)...)
+	}
+	for i := 0; i < columns.Storages; i++ {
+		result = append(result, storageColumns(i)...)
+	}
+
+	return append(result, []csvColumn{
+		{
+			name: "screen.size_inch",
+			get: func(laptop *pb.Laptop) string {
+				return ifSet(laptop.Screen != nil, strconv.FormatFloat(float64(laptop.GetScreen().GetSizeInch()), 'f', -1, 32))
+			},
+			set: func(laptop *pb.Laptop, value string) error {
+				size, err := strconv.ParseFloat(value, 32)
+				screen(laptop).SizeInch = float32(size)
+				return err
+			},
+		},
+		{
+			name: "screen.resolution",
+			get: func(laptop *pb.Laptop) string {
+				resolution := laptop.GetScreen().GetResolution()
+				if resolution == nil {
+					return ""
+				}
+				return fmt.Sprintf("%dx%d", resolution.GetWidth(), resolution.GetHeight())
+			},
+			set: func(laptop *pb.Laptop, value string) error {
+				resolution := &pb.Screen_Resolution{}
+				_, err := fmt.Sscanf(strings.ToLower(value), "%dx%d", &resolution.Width, &resolution.Height)
+				if err != nil {
+					return fmt.Errorf("resolution %q is not like 1920x1080", value)
+				}
+				screen(laptop).Resolution = resolution
+				return nil
+			},
+		},
+		{
+			name: "screen.panel",
+			get: func(laptop *pb.Laptop) string {
+				return ifSet(laptop.Screen != nil, laptop.GetScreen().GetPanel().String())
+			},
+			set: func(laptop *pb.Laptop, value string) error {
+				number, err := parseEnum(value, pb.Screen_Panel_value)
+				screen(laptop).Panel = pb.Screen_Panel(number)
+				return err
+			},
+		},
+		{
+			name: "screen.multitouch",
+			get: func(laptop *pb.Laptop) string {
+				return ifSet(laptop.Screen != nil, strconv.FormatBool(laptop.GetScreen().GetMutiltouch()))
+			},
+			set: func(laptop *pb.Laptop, value string) error {
+				return parseBool(value, &screen(laptop).Mutiltouch)
+			},
+		},
+		{
+			name: "keyboard.layout",
+			get: func(laptop *pb.Laptop) string {
+				return ifSet(laptop.Keyboard != nil, laptop.GetKeyboard().GetLayout().String())
+			},
+			set: func(laptop *pb.Laptop, value string) error {
+				number, err := parseEnum(value, pb.Keyboard_Layout_value)
+				keyboard(laptop).Layout = pb.Keyboard_Layout(number)
+				return err
+			},
+		},
+		{
+			name: "keyboard.backlit",
+			get: func(laptop *pb.Laptop) string {
+				return ifSet(laptop.Keyboard != nil, strconv.FormatBool(laptop.GetKeyboard().GetBacklit()))
+			},
+			set: func(laptop *pb.Laptop, value string) error {
+				return parseBool(value, &keyboard(laptop).Backlit)
+			},
+		},
+		{
+			name: "weight_kg",
+			get: func(laptop *pb.Laptop) string {
+				_, ok := laptop.GetWeight().(*pb.Laptop_WeightKg)
+				return ifSet(ok, formatFloat(laptop.GetWeightKg()))
+			},
+			set: func(laptop *pb.Laptop, value string) error {
+				if laptop.Weight != nil {
+					return fmt.Errorf("weight_kg and weight_lb are both set")
+				}
+				weight := &pb.Laptop_WeightKg{}
+				laptop.Weight = weight
+				return parseFloat(value, &weight.WeightKg)
+			},
+		},
+		{
+			name: "weight_lb",
+			get: func(laptop *pb.Laptop) string {
+				_, ok := laptop.GetWeight().(*pb.Laptop_WeightLb)
+				return ifSet(ok, formatFloat(laptop.GetWeightLb()))
+			},
+			set: func(laptop *pb.Laptop, value string) error {
+				if laptop.Weight != nil {
+					return fmt.Errorf("weight_kg and weight_lb are both set")
+				}
+				weight := &pb.Laptop_WeightLb{}
+				laptop.Weight = weight
+				return parseFloat(value, &weight.WeightLb)
+			},
+		},
+		{
+			name: "price_usd",
+			get:  func(laptop *pb.Laptop) string { return formatFloat(laptop.GetPriceUsd()) },
+			set:  func(laptop *pb.Laptop, value string) error { return parseFloat(value, &laptop.PriceUsd) },
+		},
+		{
+			name: "release_year",
+			get:  func(laptop *pb.Laptop) string { return formatUint(laptop.GetReleaseYear()) },
+			set:  func(laptop *pb.Laptop, value string) error { return parseUint(value, &laptop.ReleaseYear) },
+		},
+		{
+			name: "updated_at",
+			get: func(laptop *pb.Laptop) string {
+				if laptop.GetUpdatedAt() == nil {
+					return ""
+				}
+				return laptop.GetUpdatedAt().AsTime().Format(time.RFC3339Nano)
+			},
+			set: func(laptop *pb.Laptop, value string) error {
+				updatedAt, err := time.Parse(time.RFC3339Nano, value)
+				laptop.UpdatedAt = timestamppb.New(updatedAt)
+				return err
+			},
+		},
+		{
+			name: "revision",
+			get:  func(laptop *pb.Laptop) string { return strconv.FormatUint(laptop.GetRevision(), 10) },
+			set: func(laptop *pb.Laptop, value string) error {
+				var err error
+				laptop.Revision, err = strconv.ParseUint(value, 10, 64)
+				return err
+			},
+		},
+	}...)
+}
+
+// gpuColumns returns the columns of the GPU at index i
+func gpuColumns(i int) []csvColumn {
+	prefix := fmt.Sprintf("gpu%d.", i+1)
+	gpu := func(laptop *pb.Laptop) *pb.GPU {
+		if i < len(laptop.GetGpus()) {
+			return laptop.Gpus[i]
+		}
+		return nil
+	}
+	// the GPUs before index i are created empty if their cells are empty
+	setGPU := func(laptop *pb.Laptop) *pb.GPU {
+		for len(laptop.Gpus) <= i {
+			laptop.Gpus = append(laptop.Gpus, &pb.GPU{})
+		}
+		return laptop.Gpus[i]
+	}
+
+	return []csvColumn{
+		{
+			name: prefix + "brand",
+			get:  func(laptop *pb.Laptop) string { return gpu(laptop).GetBrand() },
+			set:  func(laptop *pb.Laptop, value string) error { setGPU(laptop).Brand = value; return nil },
+		},
+		{
+			name: prefix + "name",
+			get:  func(laptop *pb.Laptop) string { return gpu(laptop).GetName() },
+			set:  func(laptop *pb.Laptop, value string) error { setGPU(laptop).Name = value; return nil },
+		},
+		{
+			name: prefix + "min_ghz",
+			get:  func(laptop *pb.Laptop) string { return ifSet(gpu(laptop) != nil, formatFloat(gpu(laptop).GetMinGhz())) },
+			set:  func(laptop *pb.Laptop, value string) error { return parseFloat(value, &setGPU(laptop).MinGhz) },
+		},
+		{
+			name: prefix + "max_ghz",
+			get:  func(laptop *pb.Laptop) string { return ifSet(gpu(laptop) != nil, formatFloat(gpu(laptop).GetMaxGhz())) },
+			set:  func(laptop *pb.Laptop, value string) error { return parseFloat(value, &setGPU(laptop).MaxGhz) },
+		},
+		{
+			name: prefix + "memory",
+			get:  func(laptop *pb.Laptop) string { return FormatMemory(gpu(laptop).GetMemory()) },
+			set: func(laptop *pb.Laptop, value string) error {
+				var err error
+				setGPU(laptop).Memory, err = ParseMemory(value)
+				return err
+			},
+		},
+	}
+}
+
+// storageColumns returns the columns of the storage at index i
+func storageColumns(i int) []csvColumn {
+	prefix := fmt.Sprintf("storage%d.", i+1)
+	storage := func(laptop *pb.Laptop) *pb.Storage {
+		if i < len(laptop.GetStorages()) {
+			return laptop.Storages[i]
+		}
+		return nil
+	}
+	setStorage := func(laptop *pb.Laptop) *pb.Storage {
+		for len(laptop.Storages) <= i {
+			laptop.Storages = append(laptop.Storages, &pb.Storage{})
+		}
+		return laptop.Storages[i]
+	}
+
+	return []csvColumn{
+		{
+			name: prefix + "driver",
+			get: func(laptop *pb.Laptop) string {
+				return ifSet(storage(laptop) != nil, storage(laptop).GetDriver().String())
+			},
+			set: func(laptop *pb.Laptop, value string) error {
+				number, err := parseEnum(value, pb.Storage_Driver_value)
+				setStorage(laptop).Driver = pb.Storage_Driver(number)
+				return err
+			},
+		},
+		{
+			name: prefix + "memory",
+			get:  func(laptop *pb.Laptop) string { return FormatMemory(storage(laptop).GetMemory()) },
+			set: func(laptop *pb.Laptop, value string) error {
+				var err error
+				setStorage(laptop).Memory, err = ParseMemory(value)
+				return err
+			},
+		},
+	}
+}
+
+// memoryUnits are the suffixes of the memory units
+var memoryUnits = map[pb.Memory_Unit]string{
+	pb.Memory_BIT:      "bit",
+	pb.Memory_BYTE:     "B",
+	pb.Memory_KILOBYTE: "KB",
+	pb.Memory_MEGABYTE: "MB",
+	pb.Memory_GIGABYTE: "GB",
+	pb.Memory_TERABYTE: "TB",
+}
+
+// FormatMemory writes a memory size with its unit such as 16GB,
+// a size of unknown unit is only a number and a nil memory is empty
+func FormatMemory(memory *pb.Memory) string {
+	if memory == nil {
+		return ""
+	}
+	return strconv.FormatUint(memory.GetValue(), 10) + memoryUnits[memory.GetUnit()]
+}
+
+// ParseMemory reads a memory size written by FormatMemory, the unit is case insensitive
+func ParseMemory(text string) (*pb.Memory, error) {
+	text = strings.TrimSpace(text)
+	end := 0
+	for end < len(text) && text[end] >= '0' && text[end] <= '9' {
+		end++
+	}
+	value, err := strconv.ParseUint(text[:end], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("memory %q doesn't start with a number", text)
+	}
+
+	suffix := strings.TrimSpace(text[end:])
+	if suffix == "" {
+		return &pb.Memory{Value: value}, nil
+	}
+	for unit, name := range memoryUnits {
+		// B is a byte and b a bit in the usual notation, bit is the only written form of a bit here
+		if strings.EqualFold(suffix, name) && (unit != pb.Memory_BYTE || suffix == "B") {
+			return &pb.Memory{Value: value, Unit: unit}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown memory unit %q", suffix)
+}
+
+// ifSet returns value if set, or an empty cell
+func ifSet(set bool, value string) string {
+	if !set {
+		return ""
+	}
+	return value
+}
+
+func formatUint(value uint32) string {
+	return strconv.FormatUint(uint64(value), 10)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func parseUint(text string, value *uint32) error {
+	number, err := strconv.ParseUint(text, 10, 32)
+	*value = uint32(number)
+	return err
+}
+
+func parseFloat(text string, value *float64) error {
+	var err error
+	*value, err = strconv.ParseFloat(text, 64)
+	return err
+}
+
+func parseBool(text string, value *bool) error {
+	var err error
+	*value, err = strconv.ParseBool(text)
+	return err
+}
+
+// parseEnum returns the number of an enum value name, case insensitive, or of its number
+func parseEnum(text string, values map[string]int32) (int32, error) {
+	number, ok := values[strings.ToUpper(text)]
+	if ok {
+		return number, nil
+	}
+	parsed, err := strconv.ParseInt(text, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unknown value %q", text)
+	}
+	return int32(parsed), nil
+}
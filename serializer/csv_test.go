@@ -0,0 +1,120 @@
+package serializer
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"library/v1/pb"
+	"library/v1/sample"
+	"strings"
+	"testing"
+)
+
+// TestCSV test laptops are written to CSV and read back
+func TestCSV(t *testing.T) {
+	t.Parallel()
+
+	laptop1 := sample.NewLaptop()
+	laptop1.Name = "Name, with \"quotes\""
+	laptop1.Gpus = append(laptop1.Gpus, sample.NewGPU(), sample.NewGPU())
+	laptop2 := sample.NewLaptop()
+	laptop2.Weight = &pb.Laptop_WeightLb{WeightLb: 4.5}
+	laptop2.Gpus = nil
+	laptop2.Screen = nil
+	laptop2.UpdatedAt = nil
+	laptops := []*pb.Laptop{laptop1, laptop2, {}}
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, WriteLaptopsToCSV(buffer, laptops))
+	require.True(t, strings.HasPrefix(buffer.String(), "id,brand,name,cpu.brand,"))
+	require.Contains(t, buffer.String(), "gpu3.memory")
+
+	others, err := ReadLaptopsFromCSV(buffer)
+	require.NoError(t, err)
+	require.Len(t, others, len(laptops))
+	for i := range laptops {
+		require.True(t, proto.Equal(laptops[i], others[i]), "laptop %d", i)
+	}
+
+	encoder := NewCSVEncoder(buffer, CSVColumns{GPUs: 1, Storages: 2})
+	require.Error(t, encoder.Encode(laptop1))
+}
+
+// TestCSVDecoder test a hand written spreadsheet
+func TestCSVDecoder(t *testing.T) {
+	t.Parallel()
+
+	input := `name,ram,storage2.memory,storage2.driver,screen.resolution,weight_kg,keyboard.layout
+Laptop 1,16 GB,1TB,ssd,2560x1440,1.5,qwertz
+Laptop 2,16 parsecs,,,,,
+Laptop 3,512mb,,,,,3
+`
+	decoder := NewCSVDecoder(strings.NewReader(input))
+
+	laptop := &pb.Laptop{}
+	require.NoError(t, decoder.Decode(laptop))
+	require.Equal(t, "Laptop 1", laptop.Name)
+	require.True(t, proto.Equal(&pb.Memory{Value: 16, Unit: pb.Memory_GIGABYTE}, laptop.Ram))
+	require.Len(t, laptop.Storages, 2)
+	require.Nil(t, laptop.Storages[0].Memory)
+	require.Equal(t, pb.Storage_SSD, laptop.Storages[1].Driver)
+	require.True(t, proto.Equal(&pb.Memory{Value: 1, Unit: pb.Memory_TERABYTE}, laptop.Storages[1].Memory))
+	require.Equal(t, uint32(2560), laptop.Screen.Resolution.Width)
+	require.Equal(t, uint32(1440), laptop.Screen.Resolution.Height)
+	require.Equal(t, 1.5, laptop.GetWeightKg())
+	require.Equal(t, pb.Keyboard_QWERTZ, laptop.Keyboard.Layout)
+
+	err := decoder.Decode(laptop)
+	require.ErrorIs(t, err, ErrInvalidMessage)
+	require.Contains(t, err.Error(), "row 3")
+	require.Contains(t, err.Error(), "ram")
+
+	require.NoError(t, decoder.Decode(laptop))
+	require.Equal(t, "Laptop 3", laptop.Name)
+	require.Nil(t, laptop.Storages)
+	require.True(t, proto.Equal(&pb.Memory{Value: 512, Unit: pb.Memory_MEGABYTE}, laptop.Ram))
+	require.Equal(t, pb.Keyboard_AZERTY, laptop.Keyboard.Layout)
+	require.Equal(t, io.EOF, decoder.Decode(laptop))
+
+	_, err = ReadLaptopsFromCSV(strings.NewReader("name,color\nLaptop,red\n"))
+	require.Error(t, err)
+}
+
+// TestParseMemory test the memory sizes of the CSV mapping
+func TestParseMemory(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		text     string
+		expected *pb.Memory
+		written  string
+	}
+
+	testCases := []testCase{
+		{text: "8bit", expected: &pb.Memory{Value: 8, Unit: pb.Memory_BIT}, written: "8bit"},
+		{text: "64B", expected: &pb.Memory{Value: 64, Unit: pb.Memory_BYTE}, written: "64B"},
+		{text: "64 kb", expected: &pb.Memory{Value: 64, Unit: pb.Memory_KILOBYTE}, written: "64KB"},
+		{text: "2TB", expected: &pb.Memory{Value: 2, Unit: pb.Memory_TERABYTE}, written: "2TB"},
+		{text: "12", expected: &pb.Memory{Value: 12}, written: "12"},
+		{text: "64b"},
+		{text: "GB"},
+		{text: "-1GB"},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.text, func(t *testing.T) {
+			t.Parallel()
+
+			memory, err := ParseMemory(tc.text)
+			if tc.expected == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tc.expected, memory))
+			require.Equal(t, tc.written, FormatMemory(memory))
+		})
+	}
+}
@@ -2,7 +2,7 @@ package serializer
 
 import (
 	"fmt"
-	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/proto"
 	"io/ioutil"
 )
 
@@ -21,6 +21,47 @@ func WriteProtobufToJSONFile(message proto.Message, filename string) error {
 	return nil
 }
 
+func ReadProtobufFromJSONFile(filename string, message proto.Message) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("cannot read file from filename: %w", err)
+	}
+
+	err = JSONToProtobuf(string(data), message)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal json to message: %w", err)
+	}
+	return nil
+}
+
+// YAML File Serializer
+
+func WriteProtobufToYAMLFile(message proto.Message, filename string) error {
+	data, err := MarshalYAML(message, DefaultJSONOptions)
+	if err != nil {
+		return fmt.Errorf("cannot marshal protobuf to yaml: %w", err)
+	}
+
+	err = ioutil.WriteFile(filename, data, 0644)
+	if err != nil {
+		return fmt.Errorf("cannot write file to filename: %w", err)
+	}
+	return nil
+}
+
+func ReadProtobufFromYAMLFile(filename string, message proto.Message) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("cannot read file from filename: %w", err)
+	}
+
+	err = UnmarshalYAML(data, message, DefaultJSONOptions)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal yaml to message: %w", err)
+	}
+	return nil
+}
+
 // Binary File Serializer
 
 func WriteProtobufToBinaryFile(message proto.Message, filename string) error {
@@ -1,8 +1,8 @@
 package serializer
 
 import (
-	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	"library/v1/pb"
 	"library/v1/sample"
 	"testing"
@@ -23,4 +23,8 @@ func TestFileSerializer(t *testing.T) {
 	jsonFile := "../tmp/laptop.json"
 	err = WriteProtobufToJSONFile(laptop1, jsonFile)
 	require.NoError(t, err)
+	laptop3 := &pb.Laptop{}
+	err = ReadProtobufFromJSONFile(jsonFile, laptop3)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(laptop1, laptop3))
 }
@@ -1,18 +1,61 @@
 package serializer
 
 import (
-	"github.com/golang/protobuf/jsonpb"
-	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
-func ProtobufToJSON(message proto.Message) (string, error) {
-	marshaller := jsonpb.Marshaler{
-		EnumsAsInts:  false,
-		EmitDefaults: true,
-		Indent:       "  ",
-		OrigName:     true, // "min_ghz" format in proto file
-		//OrigName:     false, // "minGhz" format in *pb.go file
+// JSONOptions configures how messages are written to and read from JSON
+type JSONOptions struct {
+	// OrigName names the fields as in the proto file, "min_ghz", instead of "minGhz" as in the *pb.go file.
+	// Both names are accepted when reading.
+	OrigName bool
+	// EnumsAsInts writes the numbers of the enum values instead of their names
+	EnumsAsInts bool
+	// EmitDefaults writes the fields that have their zero value
+	EmitDefaults bool
+	// Indent writes a message on several lines indented by Indent, the NDJSON encoder ignores it
+	Indent string
+	// DiscardUnknown ignores the unknown fields when reading instead of failing
+	DiscardUnknown bool
+}
+
+// DefaultJSONOptions are the options of ProtobufToJSON and of the JSON files
+var DefaultJSONOptions = JSONOptions{
+	OrigName:     true,
+	EmitDefaults: true,
+	Indent:       "  ",
+}
+
+func (options JSONOptions) marshalOptions() protojson.MarshalOptions {
+	return protojson.MarshalOptions{
+		Multiline:       options.Indent != "",
+		Indent:          options.Indent,
+		UseProtoNames:   options.OrigName,
+		UseEnumNumbers:  options.EnumsAsInts,
+		EmitUnpopulated: options.EmitDefaults,
 	}
+}
+
+func (options JSONOptions) unmarshalOptions() protojson.UnmarshalOptions {
+	return protojson.UnmarshalOptions{DiscardUnknown: options.DiscardUnknown}
+}
+
+// MarshalJSON writes a message to JSON
+func MarshalJSON(message proto.Message, options JSONOptions) ([]byte, error) {
+	return options.marshalOptions().Marshal(message)
+}
+
+// UnmarshalJSON reads a message from JSON
+func UnmarshalJSON(data []byte, message proto.Message, options JSONOptions) error {
+	return options.unmarshalOptions().Unmarshal(data, message)
+}
+
+func ProtobufToJSON(message proto.Message) (string, error) {
+	data, err := MarshalJSON(message, DefaultJSONOptions)
+	return string(data), err
+}
 
-	return marshaller.MarshalToString(message)
+func JSONToProtobuf(data string, message proto.Message) error {
+	return UnmarshalJSON([]byte(data), message, DefaultJSONOptions)
 }
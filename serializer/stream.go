@@ -0,0 +1,230 @@
+package serializer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+	"io"
+)
+
+// MaxMessageSize is the largest message a Decoder reads, a binary length or JSON line above it is an error
+const MaxMessageSize = 64 << 20
+
+// ErrInvalidMessage is returned by Decode when a message cannot be unmarshaled
+// but the stream is intact, so the next messages can still be decoded
+var ErrInvalidMessage = errors.New("invalid message")
+
+// streamFormat is the format of the messages of a stream
+type streamFormat int
+
+const (
+	binaryStream streamFormat = iota
+	jsonStream
+	yamlStream
+)
+
+// Encoder writes a stream of messages: binary messages each prefixed by its varint length,
+// JSON messages each on its own line (NDJSON) or YAML documents separated by "---".
+// Every message is written by one Write call, wrap the writer in a bufio.Writer to batch them.
+type Encoder struct {
+	writer  io.Writer
+	format  streamFormat
+	options JSONOptions
+	count   int // number of messages written
+}
+
+// NewBinaryEncoder returns an encoder of length-delimited binary messages
+func NewBinaryEncoder(writer io.Writer) *Encoder {
+	return &Encoder{writer: writer, format: binaryStream}
+}
+
+// NewJSONEncoder returns an encoder of newline-delimited JSON messages, options.Indent is ignored
+func NewJSONEncoder(writer io.Writer, options JSONOptions) *Encoder {
+	options.Indent = ""
+	return &Encoder{writer: writer, format: jsonStream, options: options}
+}
+
+// NewYAMLEncoder returns an encoder of YAML documents, options.Indent is ignored
+func NewYAMLEncoder(writer io.Writer, options JSONOptions) *Encoder {
+	return &Encoder{writer: writer, format: yamlStream, options: options}
+}
+
+// Encode writes a message to the stream
+func (encoder *Encoder) Encode(message proto.Message) error {
+	var data []byte
+	switch encoder.format {
+	case jsonStream:
+		var err error
+		data, err = MarshalJSON(message, encoder.options)
+		if err != nil {
+			return fmt.Errorf("cannot marshal message to json: %w", err)
+		}
+		data = append(data, '\n')
+	case yamlStream:
+		document, err := MarshalYAML(message, encoder.options)
+		if err != nil {
+			return fmt.Errorf("cannot marshal message to yaml: %w", err)
+		}
+		if encoder.count > 0 {
+			data = []byte("---\n")
+		}
+		data = append(data, document...)
+	default:
+		size := proto.Size(message)
+		data = make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+size)
+		n := binary.PutUvarint(data, uint64(size))
+
+		var err error
+		data, err = proto.MarshalOptions{}.MarshalAppend(data[:n], message)
+		if err != nil {
+			return fmt.Errorf("cannot marshal message to binary: %w", err)
+		}
+	}
+
+	_, err := encoder.writer.Write(data)
+	if err != nil {
+		return err
+	}
+	encoder.count++
+	return nil
+}
+
+// Decoder reads the messages of an Encoder stream, it reads ahead of the message it returns
+type Decoder struct {
+	reader  *bufio.Reader
+	yaml    *yaml.Decoder
+	format  streamFormat
+	options JSONOptions
+	count   int // number of messages read
+}
+
+// NewBinaryDecoder returns a decoder of length-delimited binary messages
+func NewBinaryDecoder(reader io.Reader) *Decoder {
+	return &Decoder{reader: bufio.NewReader(reader), format: binaryStream}
+}
+
+// NewJSONDecoder returns a decoder of newline-delimited JSON messages, blank lines are skipped
+func NewJSONDecoder(reader io.Reader, options JSONOptions) *Decoder {
+	return &Decoder{reader: bufio.NewReader(reader), format: jsonStream, options: options}
+}
+
+// NewYAMLDecoder returns a decoder of YAML documents, empty documents are skipped
+func NewYAMLDecoder(reader io.Reader, options JSONOptions) *Decoder {
+	return &Decoder{yaml: yaml.NewDecoder(reader), format: yamlStream, options: options}
+}
+
+// Decode reads the next message of the stream into message,
+// it returns io.EOF when the stream ends between two messages
+func (decoder *Decoder) Decode(message proto.Message) error {
+	var err error
+	switch decoder.format {
+	case jsonStream:
+		err = decoder.decodeJSON(message)
+	case yamlStream:
+		err = decoder.decodeYAML(message)
+	default:
+		err = decoder.decodeBinary(message)
+	}
+	if err == io.EOF {
+		return io.EOF
+	}
+
+	decoder.count++
+	if err != nil {
+		return fmt.Errorf("message %d: %w", decoder.count, err)
+	}
+	return nil
+}
+
+func (decoder *Decoder) decodeBinary(message proto.Message) error {
+	length, err := binary.ReadUvarint(decoder.reader)
+	if err == io.EOF {
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read message length: %w", err)
+	}
+	if length > MaxMessageSize {
+		return fmt.Errorf("message length %d is too large", length)
+	}
+
+	data := make([]byte, length)
+	_, err = io.ReadFull(decoder.reader, data)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read message data: %w", err)
+	}
+
+	err = proto.Unmarshal(data, message)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+	return nil
+}
+
+func (decoder *Decoder) decodeJSON(message proto.Message) error {
+	for {
+		line, err := decoder.readLine()
+		if err != nil {
+			return err
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		err = UnmarshalJSON(line, message, decoder.options)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+		}
+		return nil
+	}
+}
+
+func (decoder *Decoder) decodeYAML(message proto.Message) error {
+	for {
+		node := &yaml.Node{}
+		err := decoder.yaml.Decode(node)
+		if err != nil {
+			return err
+		}
+		if len(node.Content) == 0 || node.Content[0].ShortTag() == "!!null" {
+			continue
+		}
+
+		err = unmarshalYAMLNode(node, message, decoder.options)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+		}
+		return nil
+	}
+}
+
+// readLine returns the next line without reading more than MaxMessageSize in memory,
+// the last line doesn't need to end with a newline
+func (decoder *Decoder) readLine() ([]byte, error) {
+	var line []byte
+	for {
+		fragment, err := decoder.reader.ReadSlice('\n')
+		if len(line)+len(fragment) > MaxMessageSize {
+			return nil, fmt.Errorf("line is longer than %d bytes", MaxMessageSize)
+		}
+		line = append(line, fragment...)
+
+		switch {
+		case err == bufio.ErrBufferFull:
+			continue
+		case err == io.EOF && len(line) > 0:
+			return line, nil
+		default:
+			return line, err
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package serializer
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"library/v1/pb"
+	"library/v1/sample"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestJSONFileSerializer test a JSON file is read back to the same message
+func TestJSONFileSerializer(t *testing.T) {
+	t.Parallel()
+
+	jsonFile := filepath.Join(t.TempDir(), "laptop.json")
+	laptop1 := sample.NewLaptop()
+	err := WriteProtobufToJSONFile(laptop1, jsonFile)
+	require.NoError(t, err)
+
+	laptop2 := &pb.Laptop{}
+	err = ReadProtobufFromJSONFile(jsonFile, laptop2)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(laptop1, laptop2))
+}
+
+// TestYAMLFileSerializer test a YAML file is read back to the same message
+func TestYAMLFileSerializer(t *testing.T) {
+	t.Parallel()
+
+	yamlFile := filepath.Join(t.TempDir(), "laptop.yaml")
+	laptop1 := sample.NewLaptop()
+	laptop1.Name = "true"
+	err := WriteProtobufToYAMLFile(laptop1, yamlFile)
+	require.NoError(t, err)
+
+	laptop2 := &pb.Laptop{}
+	err = ReadProtobufFromYAMLFile(yamlFile, laptop2)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(laptop1, laptop2))
+}
+
+// TestUnmarshalYAML test a hand written YAML fixture
+func TestUnmarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	fixture := `
+brand: Lenovo
+name: 1234
+cpu:
+  num_cores: 0x8
+  minGhz: 2.5
+ram: {value: 16, unit: GIGABYTE}
+gpus:
+  - name: RTX 3070
+    memory: &memory
+      value: 8
+      unit: 5
+storages:
+  - memory: *memory
+price_usd: .inf
+`
+	laptop := &pb.Laptop{}
+	err := UnmarshalYAML([]byte(fixture), laptop, DefaultJSONOptions)
+	require.NoError(t, err)
+	require.Equal(t, "Lenovo", laptop.Brand)
+	require.Equal(t, "1234", laptop.Name)
+	require.Equal(t, uint32(8), laptop.Cpu.NumCores)
+	require.Equal(t, 2.5, laptop.Cpu.MinGhz)
+	require.True(t, proto.Equal(&pb.Memory{Value: 16, Unit: pb.Memory_GIGABYTE}, laptop.Ram))
+	require.Equal(t, "RTX 3070", laptop.Gpus[0].Name)
+	require.True(t, proto.Equal(laptop.Gpus[0].Memory, laptop.Storages[0].Memory))
+	require.True(t, math.IsInf(laptop.PriceUsd, 1))
+
+	err = UnmarshalYAML([]byte("brand: [Lenovo]"), laptop, DefaultJSONOptions)
+	require.Error(t, err)
+}
+
+// TestJSONOptions test the field names, enums and default values of the JSON options
+func TestJSONOptions(t *testing.T) {
+	t.Parallel()
+
+	memory := &pb.Memory{Unit: pb.Memory_GIGABYTE}
+
+	type testCase struct {
+		name     string
+		options  JSONOptions
+		expected string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "default",
+			options:  JSONOptions{},
+			expected: `{"unit":"GIGABYTE"}`,
+		},
+		{
+			name:     "enums_as_ints",
+			options:  JSONOptions{EnumsAsInts: true},
+			expected: `{"unit":5}`,
+		},
+		{
+			name:     "emit_defaults",
+			options:  JSONOptions{EmitDefaults: true},
+			expected: `{"value":"0","unit":"GIGABYTE"}`,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := MarshalJSON(memory, tc.options)
+			require.NoError(t, err)
+			require.JSONEq(t, tc.expected, string(data))
+
+			other := &pb.Memory{}
+			require.NoError(t, UnmarshalJSON(data, other, tc.options))
+			require.True(t, proto.Equal(memory, other))
+		})
+	}
+
+	cpu := &pb.CPU{NumCores: 4, MinGhz: 2.5}
+	data, err := MarshalJSON(cpu, JSONOptions{OrigName: true})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"num_cores":4,"min_ghz":2.5}`, string(data))
+	data, err = MarshalJSON(cpu, JSONOptions{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"numCores":4,"minGhz":2.5}`, string(data))
+}
+
+// TestStream test many messages are encoded to a stream and decoded back
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name       string
+		newEncoder func(writer io.Writer) *Encoder
+		newDecoder func(reader io.Reader) *Decoder
+	}
+
+	testCases := []testCase{
+		{
+			name:       "binary",
+			newEncoder: NewBinaryEncoder,
+			newDecoder: NewBinaryDecoder,
+		},
+		{
+			name: "json",
+			newEncoder: func(writer io.Writer) *Encoder {
+				return NewJSONEncoder(writer, DefaultJSONOptions)
+			},
+			newDecoder: func(reader io.Reader) *Decoder {
+				return NewJSONDecoder(reader, DefaultJSONOptions)
+			},
+		},
+		{
+			name: "yaml",
+			newEncoder: func(writer io.Writer) *Encoder {
+				return NewYAMLEncoder(writer, DefaultJSONOptions)
+			},
+			newDecoder: func(reader io.Reader) *Decoder {
+				return NewYAMLDecoder(reader, DefaultJSONOptions)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			laptops := []*pb.Laptop{sample.NewLaptop(), {}, sample.NewLaptop()}
+			buffer := &bytes.Buffer{}
+			encoder := tc.newEncoder(buffer)
+			for _, laptop := range laptops {
+				require.NoError(t, encoder.Encode(laptop))
+			}
+			data := buffer.Bytes()
+
+			decoder := tc.newDecoder(bytes.NewReader(data))
+			for _, laptop := range laptops {
+				other := &pb.Laptop{}
+				require.NoError(t, decoder.Decode(other))
+				require.True(t, proto.Equal(laptop, other))
+			}
+			require.Equal(t, io.EOF, decoder.Decode(&pb.Laptop{}))
+
+			// a stream cut in a message is not a clean end
+			decoder = tc.newDecoder(bytes.NewReader(data[:len(data)-2]))
+			require.NoError(t, decoder.Decode(&pb.Laptop{}))
+			require.NoError(t, decoder.Decode(&pb.Laptop{}))
+			err := decoder.Decode(&pb.Laptop{})
+			require.Error(t, err)
+			require.NotEqual(t, io.EOF, err)
+		})
+	}
+}
+
+// TestJSONDecoderInvalidMessage test the JSON decoder goes on after a line that is not a message
+func TestJSONDecoderInvalidMessage(t *testing.T) {
+	t.Parallel()
+
+	input := "{\"id\": \"1\"}\n\n{\"price_usd\": \"cheap\"}\n{\"id\": \"3\"}"
+	decoder := NewJSONDecoder(strings.NewReader(input), DefaultJSONOptions)
+
+	laptop := &pb.Laptop{}
+	require.NoError(t, decoder.Decode(laptop))
+	require.Equal(t, "1", laptop.Id)
+	err := decoder.Decode(&pb.Laptop{})
+	require.ErrorIs(t, err, ErrInvalidMessage)
+	require.Contains(t, err.Error(), "message 2")
+
+	laptop = &pb.Laptop{}
+	require.NoError(t, decoder.Decode(laptop))
+	require.Equal(t, "3", laptop.Id)
+	require.Equal(t, io.EOF, decoder.Decode(&pb.Laptop{}))
+}
@@ -0,0 +1,223 @@
+package serializer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+	"math"
+	"strconv"
+)
+
+// yamlIndent is the indentation of the YAML mappings and sequences
+const yamlIndent = 2
+
+// MarshalYAML writes a message to YAML, its fields and values are the ones of the JSON mapping.
+// options.Indent is ignored.
+func MarshalYAML(message proto.Message, options JSONOptions) ([]byte, error) {
+	node, err := yamlNode(message, options)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buffer)
+	encoder.SetIndent(yamlIndent)
+	err = encoder.Encode(node)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalYAML reads a message from YAML
+func UnmarshalYAML(data []byte, message proto.Message, options JSONOptions) error {
+	node := &yaml.Node{}
+	err := yaml.Unmarshal(data, node)
+	if err != nil {
+		return err
+	}
+	return unmarshalYAMLNode(node, message, options)
+}
+
+// yamlNode converts a message to a YAML document through its JSON mapping
+func yamlNode(message proto.Message, options JSONOptions) (*yaml.Node, error) {
+	options.Indent = ""
+	data, err := MarshalJSON(message, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is YAML in flow style, the styles are reset to write the block style.
+	// The encoder still quotes the strings that would read as another type.
+	node := &yaml.Node{}
+	err = yaml.Unmarshal(data, node)
+	if err != nil {
+		return nil, err
+	}
+	resetStyle(node)
+	return node, nil
+}
+
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// unmarshalYAMLNode reads a message from a YAML document through its JSON mapping
+func unmarshalYAMLNode(node *yaml.Node, message proto.Message, options JSONOptions) error {
+	buffer := &bytes.Buffer{}
+	err := writeYAMLAsJSON(buffer, node, nil, message.ProtoReflect().Descriptor())
+	if err != nil {
+		return err
+	}
+	return UnmarshalJSON(buffer.Bytes(), message, options)
+}
+
+// writeYAMLAsJSON writes a YAML node in JSON, an empty document is an empty object.
+// The node is the value of field, or of a message of descriptor if field is nil:
+// the scalars of string fields are written as strings, so that "name: 1234" is the name "1234".
+func writeYAMLAsJSON(
+	buffer *bytes.Buffer,
+	node *yaml.Node,
+	field protoreflect.FieldDescriptor,
+	descriptor protoreflect.MessageDescriptor,
+) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buffer.WriteString("{}")
+			return nil
+		}
+		return writeYAMLAsJSON(buffer, node.Content[0], field, descriptor)
+	case yaml.AliasNode:
+		return writeYAMLAsJSON(buffer, node.Alias, field, descriptor)
+	case yaml.MappingNode:
+		buffer.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if key.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: mapping key must be a scalar", key.Line)
+			}
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			writeJSONString(buffer, key.Value)
+			buffer.WriteByte(':')
+
+			valueField, valueDescriptor := mappingValueField(key.Value, field, descriptor)
+			err := writeYAMLAsJSON(buffer, node.Content[i+1], valueField, valueDescriptor)
+			if err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte('}')
+		return nil
+	case yaml.SequenceNode:
+		buffer.WriteByte('[')
+		for i, child := range node.Content {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			err := writeYAMLAsJSON(buffer, child, field, descriptor)
+			if err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+		return nil
+	default:
+		if field != nil && field.Kind() == protoreflect.StringKind && node.ShortTag() != "!!null" {
+			writeJSONString(buffer, node.Value)
+			return nil
+		}
+		return writeYAMLScalarAsJSON(buffer, node)
+	}
+}
+
+// mappingValueField returns the field of the value of key in a mapping of field, or of a message of descriptor.
+// It returns nil for an unknown field, the JSON mapping reports it.
+func mappingValueField(
+	key string,
+	field protoreflect.FieldDescriptor,
+	descriptor protoreflect.MessageDescriptor,
+) (protoreflect.FieldDescriptor, protoreflect.MessageDescriptor) {
+	if field != nil {
+		if field.IsMap() {
+			return field.MapValue(), field.MapValue().Message()
+		}
+		descriptor = field.Message()
+	}
+	if descriptor == nil {
+		return nil, nil
+	}
+
+	valueField := descriptor.Fields().ByJSONName(key)
+	if valueField == nil {
+		valueField = descriptor.Fields().ByName(protoreflect.Name(key))
+	}
+	if valueField == nil {
+		return nil, nil
+	}
+	return valueField, valueField.Message()
+}
+
+func writeYAMLScalarAsJSON(buffer *bytes.Buffer, node *yaml.Node) error {
+	switch node.ShortTag() {
+	case "!!null":
+		buffer.WriteString("null")
+	case "!!bool":
+		var value bool
+		err := node.Decode(&value)
+		if err != nil {
+			return err
+		}
+		buffer.WriteString(strconv.FormatBool(value))
+	case "!!int":
+		// the integers of YAML can be written in other bases such as 0x1F
+		var value int64
+		err := node.Decode(&value)
+		if err != nil {
+			var unsigned uint64
+			if node.Decode(&unsigned) != nil {
+				return err
+			}
+			buffer.WriteString(strconv.FormatUint(unsigned, 10))
+			return nil
+		}
+		buffer.WriteString(strconv.FormatInt(value, 10))
+	case "!!float":
+		var value float64
+		err := node.Decode(&value)
+		if err != nil {
+			return err
+		}
+		// the JSON mapping writes the special values as strings
+		switch {
+		case math.IsNaN(value):
+			buffer.WriteString(`"NaN"`)
+		case math.IsInf(value, 1):
+			buffer.WriteString(`"Infinity"`)
+		case math.IsInf(value, -1):
+			buffer.WriteString(`"-Infinity"`)
+		default:
+			buffer.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+		}
+	default:
+		writeJSONString(buffer, node.Value)
+	}
+	return nil
+}
+
+func writeJSONString(buffer *bytes.Buffer, value string) {
+	data, _ := json.Marshal(value)
+	buffer.Write(data)
+}
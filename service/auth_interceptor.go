@@ -31,12 +31,12 @@ func (interceptor *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
 
 		log.Println("Unary Interceptor: ", info.FullMethod)
 
-		err := interceptor.Authorize(ctx, info.FullMethod)
+		claims, err := interceptor.Authorize(ctx, info.FullMethod)
 		if err != nil {
 			return nil, err
 		}
 
-		return handler(ctx, req)
+		return handler(ContextWithUserClaims(ctx, claims), req)
 	}
 }
 
@@ -49,45 +49,76 @@ func (interceptor *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
 
 		log.Println("stream Interceptor: ", info.FullMethod)
 
-		err := interceptor.Authorize(ss.Context(), info.FullMethod)
+		claims, err := interceptor.Authorize(ss.Context(), info.FullMethod)
 		if err != nil {
 			return err
 		}
-		return handler(srv, ss)
+		return handler(srv, &authenticatedStream{
+			ServerStream: ss,
+			ctx:          ContextWithUserClaims(ss.Context(), claims),
+		})
 	}
 }
 
-func (interceptor *AuthInterceptor) Authorize(ctx context.Context, method string) error {
+// authenticatedStream is a server stream whose context has the claims of its user
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *authenticatedStream) Context() context.Context {
+	return stream.ctx
+}
+
+type userClaimsKey struct{}
+
+// ContextWithUserClaims returns a context with the claims of the authenticated user, ctx if claims is nil
+func ContextWithUserClaims(ctx context.Context, claims *UserClaims) context.Context {
+	if claims == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, userClaimsKey{}, claims)
+}
+
+// UserClaimsFromContext returns the claims of the authenticated user of a request, or nil
+func UserClaimsFromContext(ctx context.Context) *UserClaims {
+	claims, _ := ctx.Value(userClaimsKey{}).(*UserClaims)
+	return claims
+}
+
+// Authorize checks that the user of a request has a role allowed to call method and returns its claims,
+// the claims are nil for the methods accessible to every one
+func (interceptor *AuthInterceptor) Authorize(ctx context.Context, method string) (*UserClaims, error) {
 	accessibleRoles, ok := interceptor.accessibleRoles[method]
 	if !ok {
 		// accessible every one
-		return nil
+		return nil, nil
 	}
 
 	// Get meta data
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return status.Errorf(codes.Unauthenticated, "metadata is not provided")
+		return nil, status.Errorf(codes.Unauthenticated, "metadata is not provided")
 	}
 	// Get "authorization" from meta data
 	values := md.Get("authorization")
 	if len(values) == 0 {
-		return status.Errorf(codes.Unauthenticated, "authorization key is not provided")
+		return nil, status.Errorf(codes.Unauthenticated, "authorization key is not provided")
 	}
 	log.Println("this is tokens: ", values)
 	// Check the access token valid
 	accessToken := values[0]
 	claims, err := interceptor.jwtManager.Verify(accessToken)
 	if err != nil {
-		return status.Errorf(codes.Unauthenticated, "access token is invalid")
+		return nil, status.Errorf(codes.Unauthenticated, "access token is invalid")
 	}
 
 	// verify role permission
 	for _, role := range accessibleRoles {
 		// log.Printf("path: %v ------ user: %v", role, claims.Role)
 		if role == claims.Role {
-			return nil
+			return claims, nil
 		}
 	}
-	return status.Errorf(codes.Unauthenticated, "no permission to access the RPC")
+	return nil, status.Errorf(codes.Unauthenticated, "no permission to access the RPC")
 }
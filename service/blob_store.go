@@ -0,0 +1,162 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BlobStore stores the data of the images by key, keys are slash separated paths
+type BlobStore interface {
+	// Put stores the data read until io.EOF under key, replacing the blob with the same key.
+	// Nothing is stored if data returns another error
+	Put(key string, data io.Reader) error
+	// Get returns the data of a blob, it returns ErrNotFound if it doesn't exist
+	Get(key string) (io.ReadCloser, error)
+	// Delete removes a blob, removing a missing blob is not an error
+	Delete(key string) error
+	// List returns the sorted keys of the blobs that start with prefix
+	List(prefix string) ([]string, error)
+}
+
+// ErrInvalidBlobKey is returned when a key names a file outside the folder of a LocalBlobStore
+var ErrInvalidBlobKey = errors.New("invalid blob key")
+
+// tempBlobPrefix starts the names of the files of the blobs being written
+const tempBlobPrefix = ".blob-"
+
+// LocalBlobStore stores blobs as the files of a folder, a key is the path of its file in the folder
+type LocalBlobStore struct {
+	blobFolder string
+}
+
+// NewLocalBlobStore returns a new LocalBlobStore
+func NewLocalBlobStore(blobFolder string) *LocalBlobStore {
+	return &LocalBlobStore{
+		blobFolder: blobFolder,
+	}
+}
+
+// path returns the path of the file of a blob, the keys of the files outside the blob folder are rejected
+func (store *LocalBlobStore) path(key string) (string, error) {
+	path := filepath.Join(store.blobFolder, filepath.FromSlash(key))
+	relative, err := filepath.Rel(store.blobFolder, path)
+	if err != nil || relative == "." || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidBlobKey, key)
+	}
+	return path, nil
+}
+
+func (store *LocalBlobStore) Put(key string, data io.Reader) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return fmt.Errorf("cannot create blob directory: %w", err)
+	}
+
+	// the blob is written to a temporary file renamed once complete, readers never see a partial blob
+	file, err := os.CreateTemp(filepath.Dir(path), tempBlobPrefix+"*")
+	if err != nil {
+		return fmt.Errorf("cannot create blob file: %w", err)
+	}
+	discard := func(err error) error {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+
+	_, err = io.Copy(file, data)
+	if err != nil {
+		return discard(fmt.Errorf("cannot write blob file: %w", err))
+	}
+	err = file.Sync()
+	if err != nil {
+		return discard(fmt.Errorf("cannot sync blob file: %w", err))
+	}
+	err = file.Close()
+	if err != nil {
+		return discard(fmt.Errorf("cannot close blob file: %w", err))
+	}
+
+	err = os.Rename(file.Name(), path)
+	if err != nil {
+		return discard(fmt.Errorf("cannot rename blob file: %w", err))
+	}
+	return nil
+}
+
+func (store *LocalBlobStore) Get(key string) (io.ReadCloser, error) {
+	path, err := store.path(key)
+	if err != nil {
+		return nil, err
+	}
+	// the file stays readable if the blob is deleted while it is open
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot open blob file: %w", err)
+	}
+	return file, nil
+}
+
+func (store *LocalBlobStore) Delete(key string) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("cannot remove blob file: %w", err)
+	}
+	return nil
+}
+
+func (store *LocalBlobStore) List(prefix string) ([]string, error) {
+	// only the folder of the prefix is walked
+	root := store.blobFolder
+	if slash := strings.LastIndexByte(prefix, '/'); slash >= 0 {
+		var err error
+		root, err = store.path(prefix[:slash])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	keys := []string{}
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) && path == root {
+			return filepath.SkipDir
+		}
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), tempBlobPrefix) {
+			return nil
+		}
+
+		relative, err := filepath.Rel(store.blobFolder, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(relative)
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list blob files: %w", err)
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	store := NewLocalBlobStore(filepath.Join(folder, "blobs"))
+
+	keys, err := store.List("")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	err = store.Put("ab/cd/abcd", bytes.NewReader([]byte("first")))
+	require.NoError(t, err)
+	err = store.Put("ab/cd/abcd.128", bytes.NewReader([]byte("thumbnail")))
+	require.NoError(t, err)
+	err = store.Put("ef/01/ef01", bytes.NewReader([]byte("second")))
+	require.NoError(t, err)
+	// a blob being written is not listed
+	err = ioutil.WriteFile(filepath.Join(folder, "blobs", "ab", tempBlobPrefix+"1"), nil, 0o644)
+	require.NoError(t, err)
+
+	keys, err = store.List("ab/cd/abcd")
+	require.NoError(t, err)
+	require.Equal(t, []string{"ab/cd/abcd", "ab/cd/abcd.128"}, keys)
+
+	// a blob is replaced by the blob with the same key
+	err = store.Put("ab/cd/abcd", bytes.NewReader([]byte("replaced")))
+	require.NoError(t, err)
+	reader, err := store.Get("ab/cd/abcd")
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(reader)
+	reader.Close()
+	require.NoError(t, err)
+	require.Equal(t, "replaced", string(data))
+
+	// a failed write keeps the previous blob
+	errRead := errors.New("read failed")
+	err = store.Put("ef/01/ef01", &errorReader{err: errRead})
+	require.ErrorIs(t, err, errRead)
+	reader, err = store.Get("ef/01/ef01")
+	require.NoError(t, err)
+	data, err = ioutil.ReadAll(reader)
+	reader.Close()
+	require.NoError(t, err)
+	require.Equal(t, "second", string(data))
+	files, err := ioutil.ReadDir(filepath.Join(folder, "blobs", "ef", "01"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	err = store.Delete("ab/cd/abcd")
+	require.NoError(t, err)
+	err = store.Delete("ab/cd/abcd")
+	require.NoError(t, err)
+	_, err = store.Get("ab/cd/abcd")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = os.Stat(filepath.Join(folder, "blobs", "ab", "cd", "abcd.128"))
+	require.NoError(t, err)
+}
+
+// TestLocalBlobStoreInvalidKey test the keys of the files outside the blob folder are rejected
+func TestLocalBlobStoreInvalidKey(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	store := NewLocalBlobStore(filepath.Join(folder, "blobs"))
+	secret := filepath.Join(folder, "secret.json")
+	err := ioutil.WriteFile(secret, []byte("secret"), 0o644)
+	require.NoError(t, err)
+
+	for _, key := range []string{"../secret.json", "images/../../secret.json", "..", ""} {
+		err = store.Put(key, bytes.NewReader([]byte("garbage")))
+		require.ErrorIs(t, err, ErrInvalidBlobKey, key)
+		_, err = store.Get(key)
+		require.ErrorIs(t, err, ErrInvalidBlobKey, key)
+		err = store.Delete(key)
+		require.ErrorIs(t, err, ErrInvalidBlobKey, key)
+	}
+	_, err = store.List("../")
+	require.ErrorIs(t, err, ErrInvalidBlobKey)
+	require.FileExists(t, secret)
+
+	// a key cleaned to a path in the folder is kept
+	err = store.Put("images/../ab/cd/abcd", bytes.NewReader([]byte("data")))
+	require.NoError(t, err)
+	keys, err := store.List("")
+	require.NoError(t, err)
+	require.Equal(t, []string{"ab/cd/abcd"}, keys)
+}
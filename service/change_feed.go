@@ -0,0 +1,221 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"library/v1/pb"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultFeedHistory is the number of recent events a change feed keeps to resume watchers
+	DefaultFeedHistory = 4096
+	// DefaultWatcherBuffer is the number of events a watcher can fall behind before it is disconnected
+	DefaultWatcherBuffer = 256
+)
+
+// ErrInvalidResumeToken is returned when a resume token cannot be parsed
+var ErrInvalidResumeToken = errors.New("invalid resume token")
+
+// ErrResumeTokenExpired is returned when the events after a resume token are no longer kept
+var ErrResumeTokenExpired = errors.New("resume token expired")
+
+// ErrWatcherTooSlow is the error of a watcher disconnected because it fell behind the writers
+var ErrWatcherTooSlow = errors.New("watcher is too slow")
+
+// LaptopEvent is a change of the laptops of a store,
+// its laptop is shared by every watcher and must not be modified
+type LaptopEvent struct {
+	Type        pb.WatchLaptopsResponse_Type
+	Laptop      *pb.Laptop
+	Sequence    uint64
+	ResumeToken string
+}
+
+// ChangeFeed keeps the recent events of a store and sends the new ones to watchers,
+// publishing never blocks: a watcher that falls behind is disconnected
+type ChangeFeed struct {
+	mutex    sync.Mutex
+	epoch    string // identifies the feed, so the tokens of another run of the store expire
+	sequence uint64 // sequence of the last event
+	history  []*LaptopEvent
+	next     int // position of the next event in history
+	size     int // number of events in history
+	buffer   int
+	watchers map[*LaptopWatcher]bool
+}
+
+// NewChangeFeed returns a change feed that keeps history events
+// and lets a watcher fall behind by buffer events
+func NewChangeFeed(history int, buffer int) *ChangeFeed {
+	if history <= 0 {
+		history = DefaultFeedHistory
+	}
+	if buffer <= 0 {
+		buffer = DefaultWatcherBuffer
+	}
+	return &ChangeFeed{
+		epoch:    uuid.New().String(),
+		history:  make([]*LaptopEvent, history),
+		buffer:   buffer,
+		watchers: make(map[*LaptopWatcher]bool),
+	}
+}
+
+// restoreChangeFeed returns a change feed that continues at the position of a feed of a previous run,
+// the watchers that received every event of that run resume without expired tokens
+func restoreChangeFeed(history int, buffer int, position string) (*ChangeFeed, error) {
+	epoch, sequence, err := parsePosition(position)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := NewChangeFeed(history, buffer)
+	feed.epoch = epoch
+	feed.sequence = sequence
+	return feed, nil
+}
+
+// position returns the epoch and the sequence of the last event, to restore the feed in a next run
+func (feed *ChangeFeed) position() string {
+	feed.mutex.Lock()
+	defer feed.mutex.Unlock()
+
+	return feed.epoch + ":" + strconv.FormatUint(feed.sequence, 10)
+}
+
+// publish records a change and sends it to the watchers
+func (feed *ChangeFeed) publish(eventType pb.WatchLaptopsResponse_Type, laptop *pb.Laptop) {
+	feed.mutex.Lock()
+	defer feed.mutex.Unlock()
+
+	feed.sequence++
+	event := &LaptopEvent{
+		Type:        eventType,
+		Laptop:      laptop,
+		Sequence:    feed.sequence,
+		ResumeToken: feed.resumeToken(feed.sequence),
+	}
+
+	feed.history[feed.next] = event
+	feed.next = (feed.next + 1) % len(feed.history)
+	if feed.size < len(feed.history) {
+		feed.size++
+	}
+
+	for watcher := range feed.watchers {
+		select {
+		case watcher.events <- event:
+		default:
+			watcher.err = ErrWatcherTooSlow
+			feed.disconnect(watcher)
+		}
+	}
+}
+
+// Watch returns a watcher of the events after resumeToken,
+// or of the next events if resumeToken is empty
+func (feed *ChangeFeed) Watch(resumeToken string) (*LaptopWatcher, error) {
+	feed.mutex.Lock()
+	defer feed.mutex.Unlock()
+
+	after := feed.sequence
+	if resumeToken != "" {
+		var err error
+		after, err = feed.parseResumeToken(resumeToken)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// events after the resume token that are still in history
+	missed := int(feed.sequence - after)
+	if missed > feed.size {
+		return nil, ErrResumeTokenExpired
+	}
+
+	watcher := &LaptopWatcher{
+		feed:   feed,
+		events: make(chan *LaptopEvent, feed.buffer+missed),
+	}
+	for i := missed; i > 0; i-- {
+		watcher.events <- feed.history[(feed.next-i+len(feed.history))%len(feed.history)]
+	}
+	feed.watchers[watcher] = true
+	return watcher, nil
+}
+
+// disconnect stops sending events to the watcher, the caller must hold the mutex
+func (feed *ChangeFeed) disconnect(watcher *LaptopWatcher) {
+	if feed.watchers[watcher] {
+		delete(feed.watchers, watcher)
+		close(watcher.events)
+	}
+}
+
+func (feed *ChangeFeed) resumeToken(sequence uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(feed.epoch + ":" + strconv.FormatUint(sequence, 10)))
+}
+
+// parseResumeToken returns the sequence of a resume token of the feed
+func (feed *ChangeFeed) parseResumeToken(token string) (uint64, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, ErrInvalidResumeToken
+	}
+
+	epoch, sequence, err := parsePosition(string(data))
+	if err != nil {
+		return 0, err
+	}
+
+	if epoch != feed.epoch {
+		return 0, fmt.Errorf("%w: the store has restarted", ErrResumeTokenExpired)
+	}
+	if sequence > feed.sequence {
+		return 0, ErrInvalidResumeToken
+	}
+	return sequence, nil
+}
+
+// parsePosition returns the epoch and the sequence of a "epoch:sequence" position
+func parsePosition(position string) (string, uint64, error) {
+	parts := strings.SplitN(position, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", 0, ErrInvalidResumeToken
+	}
+	sequence, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, ErrInvalidResumeToken
+	}
+	return parts[0], sequence, nil
+}
+
+// LaptopWatcher receives the events of a change feed
+type LaptopWatcher struct {
+	feed   *ChangeFeed
+	events chan *LaptopEvent
+	err    error // written before events is closed
+}
+
+// Events returns the channel of events, it is closed when the watcher is disconnected
+func (watcher *LaptopWatcher) Events() <-chan *LaptopEvent {
+	return watcher.events
+}
+
+// Err returns why the events channel was closed, ErrWatcherTooSlow or nil after Close
+func (watcher *LaptopWatcher) Err() error {
+	return watcher.err
+}
+
+// Close stops the watcher
+func (watcher *LaptopWatcher) Close() {
+	watcher.feed.mutex.Lock()
+	defer watcher.feed.mutex.Unlock()
+
+	watcher.feed.disconnect(watcher)
+}
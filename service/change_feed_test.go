@@ -0,0 +1,50 @@
+package service
+
+import (
+	"github.com/stretchr/testify/require"
+	"library/v1/pb"
+	"library/v1/sample"
+	"testing"
+)
+
+func TestChangeFeedResume(t *testing.T) {
+	t.Parallel()
+
+	feed := NewChangeFeed(4, 2)
+	watcher, err := feed.Watch("")
+	require.NoError(t, err)
+
+	laptops := make([]*pb.Laptop, 3)
+	for i := range laptops {
+		laptops[i] = sample.NewLaptop()
+		feed.publish(pb.WatchLaptopsResponse_CREATED, laptops[i])
+	}
+
+	// the third event doesn't fit in the buffer of the watcher
+	first := <-watcher.Events()
+	require.Equal(t, laptops[0].Id, first.Laptop.Id)
+	second := <-watcher.Events()
+	require.Equal(t, laptops[1].Id, second.Laptop.Id)
+	_, ok := <-watcher.Events()
+	require.False(t, ok)
+	require.ErrorIs(t, watcher.Err(), ErrWatcherTooSlow)
+
+	// resume after the last received event
+	watcher, err = feed.Watch(second.ResumeToken)
+	require.NoError(t, err)
+	defer watcher.Close()
+	third := <-watcher.Events()
+	require.Equal(t, laptops[2].Id, third.Laptop.Id)
+	require.Equal(t, second.Sequence+1, third.Sequence)
+
+	// the history keeps the last 4 events
+	for i := 0; i < 4; i++ {
+		feed.publish(pb.WatchLaptopsResponse_UPDATED, laptops[0])
+	}
+	_, err = feed.Watch(first.ResumeToken)
+	require.ErrorIs(t, err, ErrResumeTokenExpired)
+	_, err = feed.Watch("not a token")
+	require.ErrorIs(t, err, ErrInvalidResumeToken)
+	_, err = NewChangeFeed(4, 2).Watch(third.ResumeToken)
+	require.ErrorIs(t, err, ErrResumeTokenExpired)
+}
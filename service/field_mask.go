@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"library/v1/pb"
+	"strings"
+)
+
+// applyFieldMask copies the fields named in mask from src into dst.
+// An empty mask copies every field, the laptop ID can never be changed.
+func applyFieldMask(dst, src *pb.Laptop, mask *fieldmaskpb.FieldMask) error {
+	paths := mask.GetPaths()
+	if len(paths) == 0 {
+		id := dst.GetId()
+		proto.Reset(dst)
+		proto.Merge(dst, src)
+		dst.Id = id
+		return nil
+	}
+
+	// Validate every path before changing anything
+	for _, path := range paths {
+		if path == "id" {
+			return fmt.Errorf("field mask path %q is immutable", path)
+		}
+		err := checkFieldPath(dst.ProtoReflect().Descriptor(), strings.Split(path, "."))
+		if err != nil {
+			return fmt.Errorf("invalid field mask path %q: %w", path, err)
+		}
+	}
+
+	for _, path := range paths {
+		copyFieldPath(dst.ProtoReflect(), src.ProtoReflect(), strings.Split(path, "."))
+	}
+	return nil
+}
+
+// checkFieldPath checks that names is a path of fields in the message md
+func checkFieldPath(md protoreflect.MessageDescriptor, names []string) error {
+	fd := md.Fields().ByName(protoreflect.Name(names[0]))
+	if fd == nil {
+		return fmt.Errorf("unknown field %s in %s", names[0], md.FullName())
+	}
+	if len(names) == 1 {
+		return nil
+	}
+	// only singular message fields can be traversed
+	if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+		return fmt.Errorf("field %s of %s has no sub fields", names[0], md.FullName())
+	}
+	return checkFieldPath(fd.Message(), names[1:])
+}
+
+// copyFieldPath copies the field at names from src to dst, an unset src field clears dst
+func copyFieldPath(dst, src protoreflect.Message, names []string) {
+	fd := dst.Descriptor().Fields().ByName(protoreflect.Name(names[0]))
+	if len(names) == 1 {
+		if src.Has(fd) {
+			dst.Set(fd, src.Get(fd))
+		} else {
+			dst.Clear(fd)
+		}
+		return
+	}
+
+	copyFieldPath(dst.Mutable(fd).Message(), src.Get(fd).Message(), names[1:])
+}
@@ -0,0 +1,652 @@
+package service
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"library/v1/pb"
+	"library/v1/serializer"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	laptopLogFile      = "laptop.wal"
+	laptopSnapshotFile = "laptop.snapshot"
+	laptopFeedFile     = "laptop.feed"
+
+	// maxRecordSize protects the replay from allocating a corrupted record length
+	maxRecordSize = 64 << 20
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ErrStoreClosed is returned when a closed store is changed
+var ErrStoreClosed = errors.New("store is closed")
+
+// SyncPolicy decides when the write-ahead log is flushed to stable storage
+type SyncPolicy int
+
+const (
+	// SyncAlways flushes the log after every mutation
+	SyncAlways SyncPolicy = iota
+	// SyncInterval flushes the log periodically in background
+	SyncInterval
+	// SyncNever leaves flushing to the operating system
+	SyncNever
+)
+
+// ParseSyncPolicy returns the SyncPolicy of its name
+func ParseSyncPolicy(name string) (SyncPolicy, error) {
+	switch name {
+	case "always":
+		return SyncAlways, nil
+	case "interval":
+		return SyncInterval, nil
+	case "never":
+		return SyncNever, nil
+	default:
+		return 0, fmt.Errorf("unknown sync policy: %s", name)
+	}
+}
+
+// logFile is the file of the write-ahead log
+type logFile interface {
+	io.Writer
+	io.Seeker
+	Sync() error
+	Truncate(size int64) error
+	Close() error
+}
+
+// FileLaptopStoreOptions configures a FileLaptopStore
+type FileLaptopStoreOptions struct {
+	// Sync is the flush policy of the write-ahead log
+	Sync SyncPolicy
+	// SyncInterval is the flush period of SyncInterval policy
+	SyncInterval time.Duration
+	// SnapshotEvery compacts the log into a snapshot after this many records, 0 disables it
+	SnapshotEvery int
+}
+
+// FileLaptopStore keeps laptops in memory and persists every mutation
+// to a write-ahead log that is compacted into snapshot files
+type FileLaptopStore struct {
+	*InMemoryLaptopStore
+
+	mutex    sync.Mutex // serializes mutations and log writes
+	dir      string
+	options  FileLaptopStoreOptions
+	log      logFile
+	size     int64  // size of the complete records in the log
+	sequence uint64 // sequence of the last record
+	records  int    // records written since the last snapshot
+	dirty    bool   // records written since the last sync
+	closed   bool
+	failed   error // set when a failed record cannot be cut off the log, every later change fails
+	done     chan struct{}
+	feed     *ChangeFeed // changes published once they are in the log
+}
+
+// NewFileLaptopStore opens the store in dir, replaying its snapshot and write-ahead log
+func NewFileLaptopStore(dir string, options FileLaptopStoreOptions) (*FileLaptopStore, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create store directory: %w", err)
+	}
+
+	store := &FileLaptopStore{
+		InMemoryLaptopStore: newInMemoryLaptopStore(nil),
+		dir:                 dir,
+		options:             options,
+		done:                make(chan struct{}),
+	}
+
+	err = store.loadFeed()
+	if err != nil {
+		return nil, err
+	}
+	err = store.loadSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	err = store.replayLog()
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Sync == SyncInterval {
+		interval := options.SyncInterval
+		if interval <= 0 {
+			interval = time.Second
+		}
+		go store.syncLoop(interval)
+	}
+
+	return store, nil
+}
+
+// loadFeed continues the change feed of the previous run if the store was closed cleanly,
+// so the watchers that received every change resume after a restart.
+// The position file is removed, a crash of this run starts a new feed.
+func (store *FileLaptopStore) loadFeed() error {
+	filename := filepath.Join(store.dir, laptopFeedFile)
+	data, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		store.feed = NewChangeFeed(DefaultFeedHistory, DefaultWatcherBuffer)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read change feed position: %w", err)
+	}
+
+	err = os.Remove(filename)
+	if err != nil {
+		return fmt.Errorf("cannot remove change feed position: %w", err)
+	}
+	err = syncFile(store.dir)
+	if err != nil {
+		return err
+	}
+
+	store.feed, err = restoreChangeFeed(DefaultFeedHistory, DefaultWatcherBuffer, string(data))
+	if err != nil {
+		log.Printf("cannot restore change feed, the resume tokens expire: %v", err)
+		store.feed = NewChangeFeed(DefaultFeedHistory, DefaultWatcherBuffer)
+	}
+	return nil
+}
+
+// saveFeed writes the position of the change feed for the next run, the caller must hold the mutex
+func (store *FileLaptopStore) saveFeed() error {
+	filename := filepath.Join(store.dir, laptopFeedFile)
+	tmpFilename := filename + ".tmp"
+	err := ioutil.WriteFile(tmpFilename, []byte(store.feed.position()), 0644)
+	if err != nil {
+		return fmt.Errorf("cannot write change feed position: %w", err)
+	}
+	err = syncFile(tmpFilename)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmpFilename, filename)
+	if err != nil {
+		return fmt.Errorf("cannot rename change feed position: %w", err)
+	}
+	return syncFile(store.dir)
+}
+
+// loadSnapshot restores the laptops of the snapshot file if there is one
+func (store *FileLaptopStore) loadSnapshot() error {
+	filename := filepath.Join(store.dir, laptopSnapshotFile)
+	if _, err := os.Stat(filename); os.IsNotExist(err) {
+		return nil
+	}
+
+	snapshot := &pb.LaptopSnapshot{}
+	err := serializer.ReadProtobufFromBinaryFile(filename, snapshot)
+	if err != nil {
+		return fmt.Errorf("cannot read laptop snapshot: %w", err)
+	}
+
+	for _, laptop := range snapshot.GetLaptops() {
+		err = store.InMemoryLaptopStore.put(laptop)
+		if err != nil {
+			return err
+		}
+	}
+	store.sequence = snapshot.GetSequence()
+	return nil
+}
+
+// replayLog applies the log records written after the snapshot,
+// a torn record at the end of the log is left over from a crash and is cut off,
+// a bad record followed by other records is a corruption that fails the replay
+func (store *FileLaptopStore) replayLog() error {
+	filename := filepath.Join(store.dir, laptopLogFile)
+	file, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("cannot open laptop log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("cannot stat laptop log: %w", err)
+	}
+
+	reader := bufio.NewReader(file)
+	var offset int64
+	for {
+		record, size, err := readLaptopRecord(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if !errors.Is(err, io.ErrUnexpectedEOF) && offset+size < info.Size() {
+				file.Close()
+				return fmt.Errorf("laptop log is corrupted at offset %d: %w", offset, err)
+			}
+			log.Printf("truncate laptop log at offset %d: %v", offset, err)
+			err = file.Truncate(offset)
+			if err != nil {
+				file.Close()
+				return fmt.Errorf("cannot truncate laptop log: %w", err)
+			}
+			break
+		}
+		offset += size
+
+		// Records already compacted into the snapshot
+		if record.GetSequence() <= store.sequence {
+			continue
+		}
+		err = store.apply(record)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		store.sequence = record.GetSequence()
+		store.records++
+	}
+
+	_, err = file.Seek(offset, io.SeekStart)
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("cannot seek laptop log: %w", err)
+	}
+	store.log = file
+	store.size = offset
+	return nil
+}
+
+// apply changes the in-memory laptops according to a log record
+func (store *FileLaptopStore) apply(record *pb.LaptopRecord) error {
+	switch record.GetOperation() {
+	case pb.LaptopRecord_SAVE, pb.LaptopRecord_UPDATE:
+		return store.InMemoryLaptopStore.put(record.GetLaptop())
+	case pb.LaptopRecord_DELETE:
+		store.InMemoryLaptopStore.remove(record.GetId())
+		return nil
+	case pb.LaptopRecord_SAVE_ALL:
+		for _, laptop := range record.GetLaptops() {
+			err := store.InMemoryLaptopStore.put(laptop)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown laptop record operation: %v", record.GetOperation())
+	}
+}
+
+// Save implement the LaptopStore interface
+func (store *FileLaptopStore) Save(laptop *pb.Laptop) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if err := store.writable(); err != nil {
+		return err
+	}
+
+	err := store.InMemoryLaptopStore.Save(laptop)
+	if err != nil {
+		return err
+	}
+
+	err = store.append(&pb.LaptopRecord{Operation: pb.LaptopRecord_SAVE, Laptop: laptop})
+	if err != nil {
+		store.InMemoryLaptopStore.remove(laptop.Id)
+		return err
+	}
+	store.feed.publish(pb.WatchLaptopsResponse_CREATED, proto.Clone(laptop).(*pb.Laptop))
+	return nil
+}
+
+// SaveAll implement the LaptopStore interface, the batch is a single log record
+// so a crash cannot persist part of it
+func (store *FileLaptopStore) SaveAll(laptops []*pb.Laptop) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if err := store.writable(); err != nil {
+		return err
+	}
+
+	err := store.InMemoryLaptopStore.SaveAll(laptops)
+	if err != nil {
+		return err
+	}
+
+	err = store.append(&pb.LaptopRecord{Operation: pb.LaptopRecord_SAVE_ALL, Laptops: laptops})
+	if err != nil {
+		for _, laptop := range laptops {
+			store.InMemoryLaptopStore.remove(laptop.Id)
+		}
+		return err
+	}
+	for _, laptop := range laptops {
+		store.feed.publish(pb.WatchLaptopsResponse_CREATED, proto.Clone(laptop).(*pb.Laptop))
+	}
+	return nil
+}
+
+// Update implement the LaptopStore interface
+func (store *FileLaptopStore) Update(laptop *pb.Laptop, expectedRevision uint64) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if err := store.writable(); err != nil {
+		return err
+	}
+
+	previous, err := store.InMemoryLaptopStore.Find(laptop.Id)
+	if err != nil {
+		return err
+	}
+	err = store.InMemoryLaptopStore.Update(laptop, expectedRevision)
+	if err != nil {
+		return err
+	}
+
+	err = store.append(&pb.LaptopRecord{Operation: pb.LaptopRecord_UPDATE, Laptop: laptop})
+	if err != nil {
+		store.rollback(previous)
+		return err
+	}
+	store.feed.publish(pb.WatchLaptopsResponse_UPDATED, proto.Clone(laptop).(*pb.Laptop))
+	return nil
+}
+
+// Delete implement the LaptopStore interface
+func (store *FileLaptopStore) Delete(id string, expectedRevision uint64) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if err := store.writable(); err != nil {
+		return err
+	}
+
+	previous, err := store.InMemoryLaptopStore.Find(id)
+	if err != nil {
+		return err
+	}
+	err = store.InMemoryLaptopStore.Delete(id, expectedRevision)
+	if err != nil {
+		return err
+	}
+
+	err = store.append(&pb.LaptopRecord{Operation: pb.LaptopRecord_DELETE, Id: id})
+	if err != nil {
+		store.rollback(previous)
+		return err
+	}
+	store.feed.publish(pb.WatchLaptopsResponse_DELETED, previous)
+	return nil
+}
+
+// Watch implement the LaptopStore interface
+func (store *FileLaptopStore) Watch(resumeToken string) (*LaptopWatcher, error) {
+	return store.feed.Watch(resumeToken)
+}
+
+// writable returns the error of the changes of a closed or failed store, the caller must hold the mutex
+func (store *FileLaptopStore) writable() error {
+	if store.closed {
+		return ErrStoreClosed
+	}
+	return store.failed
+}
+
+// rollback restores the in-memory laptop after a failed log write
+func (store *FileLaptopStore) rollback(previous *pb.Laptop) {
+	err := store.InMemoryLaptopStore.put(previous)
+	if err != nil {
+		log.Printf("cannot roll back laptop %s: %v", previous.GetId(), err)
+	}
+}
+
+// append writes a record to the log, the caller must hold the mutex.
+// A record that is not durable is cut off the log, so the change rolled back by
+// the caller is not replayed at the next start
+func (store *FileLaptopStore) append(record *pb.LaptopRecord) error {
+	record.Sequence = store.sequence + 1
+
+	n, err := writeLaptopRecord(store.log, record)
+	if err != nil {
+		store.truncate(store.size)
+		return fmt.Errorf("cannot write laptop log: %w", err)
+	}
+
+	store.dirty = true
+	if store.options.Sync == SyncAlways {
+		err = store.sync()
+		if err != nil {
+			store.truncate(store.size)
+			return err
+		}
+	}
+	store.size += int64(n)
+	store.sequence = record.Sequence
+	store.records++
+
+	if store.options.SnapshotEvery > 0 && store.records >= store.options.SnapshotEvery {
+		// the record is already durable in the log, a failed compaction is retried later
+		err = store.snapshot()
+		if err != nil {
+			log.Printf("cannot compact laptop log: %v", err)
+		}
+	}
+	return nil
+}
+
+// truncate cuts the log off at size after a failed record, the store fails if the
+// record may stay in the log. The caller must hold the mutex
+func (store *FileLaptopStore) truncate(size int64) {
+	err := store.log.Truncate(size)
+	if err == nil {
+		_, err = store.log.Seek(size, io.SeekStart)
+	}
+	if err == nil {
+		// the failed record may be on the disk already, the truncation must be too
+		err = store.log.Sync()
+	}
+	if err != nil {
+		store.failed = fmt.Errorf("laptop log is unusable: %w", err)
+		log.Println(store.failed)
+		return
+	}
+	store.dirty = false
+}
+
+// sync flushes the log to stable storage, the caller must hold the mutex
+func (store *FileLaptopStore) sync() error {
+	if !store.dirty {
+		return nil
+	}
+	err := store.log.Sync()
+	if err != nil {
+		return fmt.Errorf("cannot sync laptop log: %w", err)
+	}
+	store.dirty = false
+	return nil
+}
+
+func (store *FileLaptopStore) syncLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-store.done:
+			return
+		case <-ticker.C:
+			store.mutex.Lock()
+			err := store.sync()
+			store.mutex.Unlock()
+			if err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+// Snapshot compacts the write-ahead log into a new snapshot file
+func (store *FileLaptopStore) Snapshot() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if err := store.writable(); err != nil {
+		return err
+	}
+	return store.snapshot()
+}
+
+// snapshot writes the snapshot next to the old one and renames it into place,
+// so a crash at any point leaves either the old or the new snapshot with a log
+// that replays on top of it. The caller must hold the mutex.
+func (store *FileLaptopStore) snapshot() error {
+	laptops, err := store.InMemoryLaptopStore.all()
+	if err != nil {
+		return err
+	}
+	snapshot := &pb.LaptopSnapshot{
+		Sequence: store.sequence,
+		Laptops:  laptops,
+	}
+
+	filename := filepath.Join(store.dir, laptopSnapshotFile)
+	tmpFilename := filename + ".tmp"
+	err = serializer.WriteProtobufToBinaryFile(snapshot, tmpFilename)
+	if err != nil {
+		return fmt.Errorf("cannot write laptop snapshot: %w", err)
+	}
+	err = syncFile(tmpFilename)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmpFilename, filename)
+	if err != nil {
+		return fmt.Errorf("cannot rename laptop snapshot: %w", err)
+	}
+	err = syncFile(store.dir)
+	if err != nil {
+		return err
+	}
+
+	// Every record is in the snapshot now, start an empty log
+	err = store.log.Truncate(0)
+	if err != nil {
+		return fmt.Errorf("cannot truncate laptop log: %w", err)
+	}
+	_, err = store.log.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("cannot seek laptop log: %w", err)
+	}
+	store.size = 0
+	store.dirty = true
+	store.records = 0
+	return store.sync()
+}
+
+// Close flushes and closes the write-ahead log,
+// and keeps the position of the change feed if every change is in the log
+func (store *FileLaptopStore) Close() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.closed {
+		return nil
+	}
+	store.closed = true
+	close(store.done)
+
+	err := store.sync()
+	if err != nil {
+		store.log.Close()
+		return err
+	}
+	err = store.log.Close()
+	if err != nil {
+		return err
+	}
+	if store.failed != nil {
+		return nil
+	}
+	return store.saveFeed()
+}
+
+// writeLaptopRecord writes a record framed by its length and checksum, it returns the written size
+func writeLaptopRecord(writer io.Writer, record *pb.LaptopRecord) (int, error) {
+	data, err := proto.Marshal(record)
+	if err != nil {
+		return 0, fmt.Errorf("cannot marshal laptop record: %w", err)
+	}
+
+	header := make([]byte, binary.MaxVarintLen64+4)
+	n := binary.PutUvarint(header, uint64(len(data)))
+	binary.BigEndian.PutUint32(header[n:], crc32.Checksum(data, crcTable))
+
+	// one write call keeps a record in one piece as much as the file system allows
+	return writer.Write(append(header[:n+4], data...))
+}
+
+// readLaptopRecord reads a record and returns its framed size, which is known
+// once the length is read even if the record is bad. It returns io.EOF only at
+// a clean record boundary, and an io.ErrUnexpectedEOF error for a record cut short
+func readLaptopRecord(reader *bufio.Reader) (*pb.LaptopRecord, int64, error) {
+	length, err := binary.ReadUvarint(reader)
+	if err == io.EOF {
+		return nil, 0, io.EOF
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("cannot read record length: %w", err)
+	}
+	varintBuf := make([]byte, binary.MaxVarintLen64)
+	size := int64(binary.PutUvarint(varintBuf, length)) + 4 + int64(length)
+	if length > maxRecordSize {
+		return nil, size, fmt.Errorf("record length %d is too large", length)
+	}
+
+	data := make([]byte, 4+length)
+	_, err = io.ReadFull(reader, data)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, size, fmt.Errorf("cannot read record data: %w", err)
+	}
+	if binary.BigEndian.Uint32(data) != crc32.Checksum(data[4:], crcTable) {
+		return nil, size, fmt.Errorf("record checksum mismatch")
+	}
+
+	record := &pb.LaptopRecord{}
+	err = proto.Unmarshal(data[4:], record)
+	if err != nil {
+		return nil, size, fmt.Errorf("cannot unmarshal record: %w", err)
+	}
+	return record, size, nil
+}
+
+// syncFile flushes a file or a directory entry to stable storage
+func syncFile(name string) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("cannot open %s: %w", name, err)
+	}
+	defer file.Close()
+
+	err = file.Sync()
+	if err != nil {
+		return fmt.Errorf("cannot sync %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"library/v1/pb"
+	"library/v1/sample"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFileLaptopStoreReplay test the store content survives a restart
+func TestFileLaptopStoreReplay(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	options := FileLaptopStoreOptions{Sync: SyncAlways, SnapshotEvery: 3}
+
+	store, err := NewFileLaptopStore(dir, options)
+	require.NoError(t, err)
+
+	laptop1 := sample.NewLaptop()
+	laptop2 := sample.NewLaptop()
+	laptop3 := sample.NewLaptop()
+	require.NoError(t, store.Save(laptop1))
+	require.NoError(t, store.Save(laptop2))
+	require.NoError(t, store.Save(laptop3))
+
+	// These records are written after the snapshot
+	laptop1.PriceUsd = 999
+	require.NoError(t, store.Update(laptop1, 1))
+	require.NoError(t, store.Delete(laptop2.Id, 0))
+	require.ErrorIs(t, store.Save(laptop3), ErrAlreadyExists)
+
+	// A batch is written as one record, a failed batch writes nothing
+	laptop4 := sample.NewLaptop()
+	laptop5 := sample.NewLaptop()
+	require.ErrorIs(t, store.SaveAll([]*pb.Laptop{sample.NewLaptop(), laptop3}), ErrAlreadyExists)
+	require.NoError(t, store.SaveAll([]*pb.Laptop{laptop4, laptop5}))
+	require.NoError(t, store.Close())
+
+	require.FileExists(t, filepath.Join(dir, laptopSnapshotFile))
+
+	store, err = NewFileLaptopStore(dir, options)
+	require.NoError(t, err)
+	defer store.Close()
+
+	other, err := store.Find(laptop1.Id)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, other.Revision)
+	requireSameLaptop(t, laptop1, other)
+
+	other, err = store.Find(laptop2.Id)
+	require.NoError(t, err)
+	require.Nil(t, other)
+
+	other, err = store.Find(laptop3.Id)
+	require.NoError(t, err)
+	requireSameLaptop(t, laptop3, other)
+
+	for _, laptop := range []*pb.Laptop{laptop4, laptop5} {
+		other, err = store.Find(laptop.Id)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, other.Revision)
+		requireSameLaptop(t, laptop, other)
+	}
+}
+
+// failingLogFile is a log file whose next syncs fail
+type failingLogFile struct {
+	logFile
+	syncFailures int
+}
+
+var errSync = errors.New("sync failed")
+
+func (file *failingLogFile) Sync() error {
+	if file.syncFailures > 0 {
+		file.syncFailures--
+		return errSync
+	}
+	return file.logFile.Sync()
+}
+
+// TestFileLaptopStoreWatchRestart test a watcher resumes after a clean restart, not after a crash
+func TestFileLaptopStoreWatchRestart(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	options := FileLaptopStoreOptions{Sync: SyncAlways}
+
+	store, err := NewFileLaptopStore(dir, options)
+	require.NoError(t, err)
+	watcher, err := store.Watch("")
+	require.NoError(t, err)
+	require.NoError(t, store.Save(sample.NewLaptop()))
+	first := <-watcher.Events()
+	watcher.Close()
+	require.NoError(t, store.Close())
+
+	store, err = NewFileLaptopStore(dir, options)
+	require.NoError(t, err)
+	watcher, err = store.Watch(first.ResumeToken)
+	require.NoError(t, err)
+	laptop := sample.NewLaptop()
+	require.NoError(t, store.Save(laptop))
+	second := <-watcher.Events()
+	watcher.Close()
+	require.Equal(t, laptop.Id, second.Laptop.Id)
+	require.Equal(t, first.Sequence+1, second.Sequence)
+
+	// the store is opened again without being closed, like after a crash
+	crashed, err := NewFileLaptopStore(dir, options)
+	require.NoError(t, err)
+	defer crashed.Close()
+	_, err = crashed.Watch(second.ResumeToken)
+	require.ErrorIs(t, err, ErrResumeTokenExpired)
+	require.NoError(t, store.Close())
+}
+
+// TestFileLaptopStoreSyncFailure test a laptop that isn't synced is not restored on restart
+func TestFileLaptopStoreSyncFailure(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	options := FileLaptopStoreOptions{Sync: SyncAlways}
+
+	store, err := NewFileLaptopStore(dir, options)
+	require.NoError(t, err)
+	file := &failingLogFile{logFile: store.log}
+	store.log = file
+
+	// the record is cut off the log
+	failed := sample.NewLaptop()
+	file.syncFailures = 1
+	require.ErrorIs(t, store.Save(failed), errSync)
+	other, err := store.Find(failed.Id)
+	require.NoError(t, err)
+	require.Nil(t, other)
+
+	saved := sample.NewLaptop()
+	require.NoError(t, store.Save(saved))
+
+	// the record cannot be cut off, the store refuses the next changes
+	file.syncFailures = 2
+	require.ErrorIs(t, store.Save(sample.NewLaptop()), errSync)
+	err = store.Save(sample.NewLaptop())
+	require.ErrorIs(t, err, errSync)
+	require.Contains(t, err.Error(), "unusable")
+	require.NoError(t, store.Close())
+
+	store, err = NewFileLaptopStore(dir, options)
+	require.NoError(t, err)
+	defer store.Close()
+
+	other, err = store.Find(failed.Id)
+	require.NoError(t, err)
+	require.Nil(t, other)
+	other, err = store.Find(saved.Id)
+	require.NoError(t, err)
+	requireSameLaptop(t, saved, other)
+}
+
+// TestFileLaptopStoreTornRecord test a partly written record is cut off on restart
+func TestFileLaptopStoreTornRecord(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	options := FileLaptopStoreOptions{Sync: SyncNever}
+
+	store, err := NewFileLaptopStore(dir, options)
+	require.NoError(t, err)
+	laptop := sample.NewLaptop()
+	require.NoError(t, store.Save(laptop))
+	require.NoError(t, store.Close())
+
+	// Simulate a crash in the middle of writing a record
+	logFile := filepath.Join(dir, laptopLogFile)
+	info, err := os.Stat(logFile)
+	require.NoError(t, err)
+	file, err := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = file.Write([]byte{0x80, 0x01, 0xde, 0xad})
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	store, err = NewFileLaptopStore(dir, options)
+	require.NoError(t, err)
+
+	other, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	requireSameLaptop(t, laptop, other)
+
+	// The store keeps appending after the last complete record
+	require.NoError(t, store.Save(sample.NewLaptop()))
+	require.NoError(t, store.Close())
+
+	truncated, err := os.Stat(logFile)
+	require.NoError(t, err)
+	require.Greater(t, truncated.Size(), info.Size())
+
+	store, err = NewFileLaptopStore(dir, options)
+	require.NoError(t, err)
+	defer store.Close()
+	laptops, err := store.all()
+	require.NoError(t, err)
+	require.Len(t, laptops, 2)
+}
+
+// TestFileLaptopStoreCorruptedRecord test a bad record is cut off the end of the log,
+// and fails the replay when it is followed by other records
+func TestFileLaptopStoreCorruptedRecord(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	options := FileLaptopStoreOptions{Sync: SyncNever}
+
+	store, err := NewFileLaptopStore(dir, options)
+	require.NoError(t, err)
+	laptops := []*pb.Laptop{sample.NewLaptop(), sample.NewLaptop(), sample.NewLaptop()}
+	for _, laptop := range laptops {
+		require.NoError(t, store.Save(laptop))
+	}
+	require.NoError(t, store.Close())
+
+	// the offsets of the records
+	logFile := filepath.Join(dir, laptopLogFile)
+	data, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	reader := bufio.NewReader(bytes.NewReader(data))
+	var offsets []int64
+	var offset int64
+	for range laptops {
+		offsets = append(offsets, offset)
+		_, size, err := readLaptopRecord(reader)
+		require.NoError(t, err)
+		offset += size
+	}
+
+	// a flipped byte in the second record
+	corrupted := append([]byte(nil), data...)
+	corrupted[offsets[2]-1] ^= 0xff
+	require.NoError(t, os.WriteFile(logFile, corrupted, 0644))
+	_, err = NewFileLaptopStore(dir, options)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), fmt.Sprintf("laptop log is corrupted at offset %d", offsets[1]))
+	info, err := os.Stat(logFile)
+	require.NoError(t, err)
+	require.EqualValues(t, len(data), info.Size())
+
+	// a flipped byte in the last record
+	corrupted = append([]byte(nil), data...)
+	corrupted[len(corrupted)-1] ^= 0xff
+	require.NoError(t, os.WriteFile(logFile, corrupted, 0644))
+	store, err = NewFileLaptopStore(dir, options)
+	require.NoError(t, err)
+	defer store.Close()
+	stored, err := store.all()
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	other, err := store.Find(laptops[2].Id)
+	require.NoError(t, err)
+	require.Nil(t, other)
+	info, err = os.Stat(logFile)
+	require.NoError(t, err)
+	require.Equal(t, offsets[2], info.Size())
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"library/v1/pb"
+)
+
+// laptopServicePath starts the full names of the methods of the laptop service
+const laptopServicePath = "/techschool.proto.LaptopService/"
+
+// GatewayLaptopServer checks the roles of the calls of the REST gateway. The gateway calls the
+// laptop server in-process, without the gRPC interceptors, and passes the Authorization header as
+// the authorization metadata, so a method requires the same roles over REST and gRPC.
+// The gateway doesn't call the streaming methods
+type GatewayLaptopServer struct {
+	pb.LaptopServiceServer
+	interceptor *AuthInterceptor
+}
+
+// NewGatewayLaptopServer returns the server of the REST gateway of a laptop server
+func NewGatewayLaptopServer(server pb.LaptopServiceServer, interceptor *AuthInterceptor) *GatewayLaptopServer {
+	return &GatewayLaptopServer{
+		LaptopServiceServer: server,
+		interceptor:         interceptor,
+	}
+}
+
+// authorize returns the context of a call with the claims of its user
+func (server *GatewayLaptopServer) authorize(ctx context.Context, method string) (context.Context, error) {
+	claims, err := server.interceptor.Authorize(ctx, laptopServicePath+method)
+	if err != nil {
+		return nil, logError(err)
+	}
+	return ContextWithUserClaims(ctx, claims), nil
+}
+
+func (server *GatewayLaptopServer) CreateLaptop(ctx context.Context, req *pb.CreateLaptopRequest) (*pb.CreateLaptopResponse, error) {
+	ctx, err := server.authorize(ctx, "CreateLaptop")
+	if err != nil {
+		return nil, err
+	}
+	return server.LaptopServiceServer.CreateLaptop(ctx, req)
+}
+
+func (server *GatewayLaptopServer) GetLaptop(ctx context.Context, req *pb.GetLaptopRequest) (*pb.GetLaptopResponse, error) {
+	ctx, err := server.authorize(ctx, "GetLaptop")
+	if err != nil {
+		return nil, err
+	}
+	return server.LaptopServiceServer.GetLaptop(ctx, req)
+}
+
+func (server *GatewayLaptopServer) UpdateLaptop(ctx context.Context, req *pb.UpdateLaptopRequest) (*pb.UpdateLaptopResponse, error) {
+	ctx, err := server.authorize(ctx, "UpdateLaptop")
+	if err != nil {
+		return nil, err
+	}
+	return server.LaptopServiceServer.UpdateLaptop(ctx, req)
+}
+
+func (server *GatewayLaptopServer) DeleteLaptop(ctx context.Context, req *pb.DeleteLaptopRequest) (*pb.DeleteLaptopResponse, error) {
+	ctx, err := server.authorize(ctx, "DeleteLaptop")
+	if err != nil {
+		return nil, err
+	}
+	return server.LaptopServiceServer.DeleteLaptop(ctx, req)
+}
+
+func (server *GatewayLaptopServer) DeleteRating(ctx context.Context, req *pb.DeleteRatingRequest) (*pb.DeleteRatingResponse, error) {
+	ctx, err := server.authorize(ctx, "DeleteRating")
+	if err != nil {
+		return nil, err
+	}
+	return server.LaptopServiceServer.DeleteRating(ctx, req)
+}
+
+func (server *GatewayLaptopServer) GetRating(ctx context.Context, req *pb.GetRatingRequest) (*pb.GetRatingResponse, error) {
+	ctx, err := server.authorize(ctx, "GetRating")
+	if err != nil {
+		return nil, err
+	}
+	return server.LaptopServiceServer.GetRating(ctx, req)
+}
+
+func (server *GatewayLaptopServer) BatchGetRatings(ctx context.Context, req *pb.BatchGetRatingsRequest) (*pb.BatchGetRatingsResponse, error) {
+	ctx, err := server.authorize(ctx, "BatchGetRatings")
+	if err != nil {
+		return nil, err
+	}
+	return server.LaptopServiceServer.BatchGetRatings(ctx, req)
+}
+
+func (server *GatewayLaptopServer) ListLaptops(ctx context.Context, req *pb.ListLaptopsRequest) (*pb.ListLaptopsResponse, error) {
+	ctx, err := server.authorize(ctx, "ListLaptops")
+	if err != nil {
+		return nil, err
+	}
+	return server.LaptopServiceServer.ListLaptops(ctx, req)
+}
+
+func (server *GatewayLaptopServer) AggregateLaptops(ctx context.Context, req *pb.AggregateLaptopsRequest) (*pb.AggregateLaptopsResponse, error) {
+	ctx, err := server.authorize(ctx, "AggregateLaptops")
+	if err != nil {
+		return nil, err
+	}
+	return server.LaptopServiceServer.AggregateLaptops(ctx, req)
+}
+
+func (server *GatewayLaptopServer) ListImages(ctx context.Context, req *pb.ListImagesRequest) (*pb.ListImagesResponse, error) {
+	ctx, err := server.authorize(ctx, "ListImages")
+	if err != nil {
+		return nil, err
+	}
+	return server.LaptopServiceServer.ListImages(ctx, req)
+}
+
+func (server *GatewayLaptopServer) GetImageContent(ctx context.Context, req *pb.GetImageContentRequest) (*httpbody.HttpBody, error) {
+	ctx, err := server.authorize(ctx, "GetImageContent")
+	if err != nil {
+		return nil, err
+	}
+	return server.LaptopServiceServer.GetImageContent(ctx, req)
+}
+
+func (server *GatewayLaptopServer) DeleteImage(ctx context.Context, req *pb.DeleteImageRequest) (*pb.DeleteImageResponse, error) {
+	ctx, err := server.authorize(ctx, "DeleteImage")
+	if err != nil {
+		return nil, err
+	}
+	return server.LaptopServiceServer.DeleteImage(ctx, req)
+}
+
+func (server *GatewayLaptopServer) InitiateUpload(ctx context.Context, req *pb.InitiateUploadRequest) (*pb.InitiateUploadResponse, error) {
+	ctx, err := server.authorize(ctx, "InitiateUpload")
+	if err != nil {
+		return nil, err
+	}
+	return server.LaptopServiceServer.InitiateUpload(ctx, req)
+}
+
+func (server *GatewayLaptopServer) QueryUpload(ctx context.Context, req *pb.QueryUploadRequest) (*pb.QueryUploadResponse, error) {
+	ctx, err := server.authorize(ctx, "QueryUpload")
+	if err != nil {
+		return nil, err
+	}
+	return server.LaptopServiceServer.QueryUpload(ctx, req)
+}
+
+func (server *GatewayLaptopServer) CommitUpload(ctx context.Context, req *pb.CommitUploadRequest) (*pb.UploadImageResponse, error) {
+	ctx, err := server.authorize(ctx, "CommitUpload")
+	if err != nil {
+		return nil, err
+	}
+	return server.LaptopServiceServer.CommitUpload(ctx, req)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"library/v1/pb"
+	"library/v1/sample"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestGatewayLaptopServerMethods test every unary method checks the roles of its user
+func TestGatewayLaptopServerMethods(t *testing.T) {
+	t.Parallel()
+
+	accessibleRoles := map[string][]string{}
+	for _, method := range pb.LaptopService_ServiceDesc.Methods {
+		accessibleRoles[laptopServicePath+method.MethodName] = []string{"admin"}
+	}
+	interceptor := NewAuthInterceptor(NewJWTManager("secret", time.Minute), accessibleRoles)
+	// a method that isn't checked by the gateway server reaches the unimplemented server
+	server := NewGatewayLaptopServer(pb.UnimplementedLaptopServiceServer{}, interceptor)
+
+	for _, method := range pb.LaptopService_ServiceDesc.Methods {
+		decode := func(interface{}) error {
+			return nil
+		}
+		_, err := method.Handler(server, context.Background(), decode, nil)
+		require.Equal(t, codes.Unauthenticated, status.Code(err), method.MethodName)
+	}
+}
+
+// TestGatewayLaptopServerREST test the REST routes require the roles of their methods
+func TestGatewayLaptopServerREST(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := NewInMemoryLaptopStore()
+	laptop := sample.NewLaptop()
+	require.NoError(t, laptopStore.Save(laptop))
+
+	ratingStore := NewInMemoryRatingStore()
+	_, err := ratingStore.Rate(laptop.GetId(), "user1", 8)
+	require.NoError(t, err)
+	imageStore := NewDiskImageStore(t.TempDir())
+	imageID, _, err := imageStore.Save(laptop.GetId(), "", bytes.NewReader(newTestImage(t, "png", 4, 3)))
+	require.NoError(t, err)
+
+	jwtManager := NewJWTManager("secret", time.Minute)
+	interceptor := NewAuthInterceptor(jwtManager, map[string][]string{
+		laptopServicePath + "DeleteLaptop": {"admin"},
+		laptopServicePath + "DeleteImage":  {"admin"},
+		laptopServicePath + "DeleteRating": {"admin", "user"},
+	})
+	mux := runtime.NewServeMux()
+	server := NewGatewayLaptopServer(NewLaptopServer(laptopStore, imageStore, ratingStore), interceptor)
+	err = pb.RegisterLaptopServiceHandlerServer(context.Background(), mux, server)
+	require.NoError(t, err)
+
+	token := func(role string) string {
+		user, err := NewUser("user1", "secret", role)
+		require.NoError(t, err)
+		token, err := jwtManager.Generate(user)
+		require.NoError(t, err)
+		return token
+	}
+	serve := func(method string, path string, token string) int {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	laptopPath := "/v1/laptop/" + laptop.GetId()
+	// the users delete their own rating
+	ratingPath := laptopPath + "/rating"
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodDelete, ratingPath, ""))
+	require.Equal(t, http.StatusOK, serve(http.MethodDelete, ratingPath, token("user")))
+	require.Equal(t, http.StatusNotFound, serve(http.MethodDelete, ratingPath, token("user")))
+
+	require.Equal(t, http.StatusOK, serve(http.MethodGet, laptopPath, ""))
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodDelete, laptopPath, ""))
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodDelete, laptopPath, "invalid"))
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodDelete, laptopPath, token("user")))
+	require.Equal(t, http.StatusOK, serve(http.MethodDelete, laptopPath, token("admin")))
+	require.Equal(t, http.StatusNotFound, serve(http.MethodGet, laptopPath, ""))
+
+	imagePath := "/v1/image/" + imageID
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodDelete, imagePath, ""))
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodDelete, imagePath, token("user")))
+	info, err := imageStore.Find(imageID)
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	require.Equal(t, http.StatusOK, serve(http.MethodDelete, imagePath, token("admin")))
+	info, err = imageStore.Find(imageID)
+	require.NoError(t, err)
+	require.Nil(t, info)
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// ErrInvalidImage is returned when the data of an image is not an image of an allowed format
+var ErrInvalidImage = errors.New("invalid image")
+
+// sniffLength is the number of bytes read to detect the format and the size of an image
+const sniffLength = 32
+
+// maxResizedPixels is the largest number of pixels of an image decoded to make its thumbnails,
+// the larger images are sent as is
+const maxResizedPixels = 50 << 20
+
+// imageFormat is a format of the images the store accepts
+type imageFormat struct {
+	name        string
+	contentType string
+	// extensions are the image types of the format, the first one is used when the type is not given
+	extensions []string
+	magic      func(header []byte) bool
+	// resizable is false if the standard library cannot decode the format
+	resizable bool
+}
+
+var imageFormats = []*imageFormat{
+	{
+		name:        "png",
+		contentType: "image/png",
+		extensions:  []string{".png"},
+		magic: func(header []byte) bool {
+			return bytes.HasPrefix(header, []byte("\x89PNG\r\n\x1a\n"))
+		},
+		resizable: true,
+	},
+	{
+		name:        "jpeg",
+		contentType: "image/jpeg",
+		extensions:  []string{".jpg", ".jpeg"},
+		magic: func(header []byte) bool {
+			return bytes.HasPrefix(header, []byte("\xff\xd8\xff"))
+		},
+		resizable: true,
+	},
+	{
+		name:        "gif",
+		contentType: "image/gif",
+		extensions:  []string{".gif"},
+		magic: func(header []byte) bool {
+			return bytes.HasPrefix(header, []byte("GIF87a")) || bytes.HasPrefix(header, []byte("GIF89a"))
+		},
+		resizable: true,
+	},
+	{
+		name:        "webp",
+		contentType: "image/webp",
+		extensions:  []string{".webp"},
+		magic: func(header []byte) bool {
+			return len(header) >= 12 && string(header[0:4]) == "RIFF" && string(header[8:12]) == "WEBP"
+		},
+	},
+}
+
+// imageFormatByName returns the format with the given name, or nil
+func imageFormatByName(name string) *imageFormat {
+	for _, format := range imageFormats {
+		if format.name == name {
+			return format
+		}
+	}
+	return nil
+}
+
+// imageFormatByType returns the format of an image type such as .jpg, or nil if the type is not allowed
+func imageFormatByType(imageType string) *imageFormat {
+	imageType = strings.ToLower(imageType)
+	for _, format := range imageFormats {
+		for _, extension := range format.extensions {
+			if extension == imageType {
+				return format
+			}
+		}
+	}
+	return nil
+}
+
+// detectImageFormat returns the format of an image from its first bytes,
+// it returns an ErrInvalidImage if the format is not allowed or is not the one of the image type
+func detectImageFormat(imageType string, header []byte) (*imageFormat, error) {
+	var format *imageFormat
+	for _, candidate := range imageFormats {
+		if candidate.magic(header) {
+			format = candidate
+			break
+		}
+	}
+	if format == nil {
+		return nil, fmt.Errorf("%w: the data is not a PNG, JPEG, GIF or WebP image", ErrInvalidImage)
+	}
+
+	if imageType == "" {
+		return format, nil
+	}
+	declared := imageFormatByType(imageType)
+	if declared == nil {
+		return nil, fmt.Errorf("%w: image type %s is not allowed", ErrInvalidImage, imageType)
+	}
+	if declared != format {
+		return nil, fmt.Errorf("%w: image type is %s but the data is a %s image", ErrInvalidImage, imageType, format.name)
+	}
+	return format, nil
+}
+
+// imageDimensions returns the width and the height of an image read from its beginning
+func imageDimensions(format *imageFormat, reader io.Reader) (int, int, error) {
+	if format.name == "webp" {
+		header := make([]byte, sniffLength)
+		n, err := io.ReadFull(reader, header)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return 0, 0, err
+		}
+		return webpDimensions(header[:n])
+	}
+
+	config, _, err := image.DecodeConfig(reader)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	return config.Width, config.Height, nil
+}
+
+// webpDimensions reads the size of a WebP image from the header of its first chunk
+func webpDimensions(header []byte) (int, int, error) {
+	if len(header) < 30 {
+		return 0, 0, fmt.Errorf("%w: WebP header is too short", ErrInvalidImage)
+	}
+
+	data := header[20:]
+	switch string(header[12:16]) {
+	case "VP8X":
+		width := int(data[4]) | int(data[5])<<8 | int(data[6])<<16
+		height := int(data[7]) | int(data[8])<<8 | int(data[9])<<16
+		return width + 1, height + 1, nil
+	case "VP8L":
+		if data[0] != 0x2f {
+			return 0, 0, fmt.Errorf("%w: invalid VP8L signature", ErrInvalidImage)
+		}
+		bits := binary.LittleEndian.Uint32(data[1:5])
+		return int(bits&0x3fff) + 1, int(bits>>14&0x3fff) + 1, nil
+	case "VP8 ":
+		if !bytes.Equal(data[3:6], []byte{0x9d, 0x01, 0x2a}) {
+			return 0, 0, fmt.Errorf("%w: invalid VP8 start code", ErrInvalidImage)
+		}
+		width := binary.LittleEndian.Uint16(data[6:8]) & 0x3fff
+		height := binary.LittleEndian.Uint16(data[8:10]) & 0x3fff
+		return int(width), int(height), nil
+	default:
+		return 0, 0, fmt.Errorf("%w: unknown WebP chunk %q", ErrInvalidImage, header[12:16])
+	}
+}
+
+// writeThumbnail writes the image read from reader resized to fit in a square of size pixels,
+// in the format of the image
+func writeThumbnail(format *imageFormat, reader io.Reader, writer io.Writer, size int) error {
+	// the first frame of an animated GIF is its thumbnail
+	src, _, err := image.Decode(reader)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	thumbnail := resizeToFit(src, size)
+
+	switch format.name {
+	case "jpeg":
+		return jpeg.Encode(writer, thumbnail, &jpeg.Options{Quality: 85})
+	case "gif":
+		return gif.Encode(writer, thumbnail, nil)
+	default:
+		return png.Encode(writer, thumbnail)
+	}
+}
+
+// resizeToFit scales an image down to fit in a square of size pixels,
+// each pixel is the average of the pixels of the image it covers
+func resizeToFit(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return src
+	}
+
+	// the thumbnail keeps the aspect ratio, with at least one pixel on each side
+	dstWidth, dstHeight := size, size
+	if width > height {
+		dstHeight = (height*size + width - 1) / width
+	} else {
+		dstWidth = (width*size + height - 1) / height
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0 := bounds.Min.Y + y*height/dstHeight
+		y1 := bounds.Min.Y + (y+1)*height/dstHeight
+		for x := 0; x < dstWidth; x++ {
+			x0 := bounds.Min.X + x*width/dstWidth
+			x1 := bounds.Min.X + (x+1)*width/dstWidth
+
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					count++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / count),
+				G: uint16(g / count),
+				B: uint16(b / count),
+				A: uint16(a / count),
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/stretchr/testify/require"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"math/rand"
+	"testing"
+)
+
+func TestDetectImageFormat(t *testing.T) {
+	t.Parallel()
+
+	pngData := newTestImage(t, "png", 4, 3)
+
+	testCases := []struct {
+		name      string
+		imageType string
+		data      []byte
+		format    string
+		err       error
+	}{
+		{
+			name:      "png",
+			imageType: ".png",
+			data:      pngData,
+			format:    "png",
+		},
+		{
+			name:      "jpeg",
+			imageType: ".JPEG",
+			data:      newTestImage(t, "jpeg", 4, 3),
+			format:    "jpeg",
+		},
+		{
+			name:   "gif_without_type",
+			data:   newTestImage(t, "gif", 4, 3),
+			format: "gif",
+		},
+		{
+			name:      "webp",
+			imageType: ".webp",
+			data:      newTestWebP(t, "VP8L", 4, 3),
+			format:    "webp",
+		},
+		{
+			name:      "failure_mismatch",
+			imageType: ".jpg",
+			data:      pngData,
+			err:       ErrInvalidImage,
+		},
+		{
+			name:      "failure_not_allowed",
+			imageType: ".bmp",
+			data:      pngData,
+			err:       ErrInvalidImage,
+		},
+		{
+			name:      "failure_unknown",
+			imageType: ".png",
+			data:      []byte("%PDF-1.7"),
+			err:       ErrInvalidImage,
+		},
+		{
+			name:      "failure_empty",
+			imageType: ".png",
+			err:       ErrInvalidImage,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			header := tc.data
+			if len(header) > sniffLength {
+				header = header[:sniffLength]
+			}
+			format, err := detectImageFormat(tc.imageType, header)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.format, format.name)
+
+			width, height, err := imageDimensions(format, bytes.NewReader(tc.data))
+			require.NoError(t, err)
+			require.Equal(t, 4, width)
+			require.Equal(t, 3, height)
+		})
+	}
+}
+
+func TestWebPDimensions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		chunk string
+	}{
+		{
+			name:  "lossy",
+			chunk: "VP8 ",
+		},
+		{
+			name:  "lossless",
+			chunk: "VP8L",
+		},
+		{
+			name:  "extended",
+			chunk: "VP8X",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			width, height, err := webpDimensions(newTestWebP(t, tc.chunk, 1920, 1080))
+			require.NoError(t, err)
+			require.Equal(t, 1920, width)
+			require.Equal(t, 1080, height)
+		})
+	}
+
+	_, _, err := webpDimensions([]byte("RIFF\x00\x00\x00\x00WEBPVP8 "))
+	require.ErrorIs(t, err, ErrInvalidImage)
+}
+
+func TestResizeToFit(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		width  int
+		height int
+		size   int
+		want   image.Point
+	}{
+		{
+			name:   "landscape",
+			width:  300,
+			height: 100,
+			size:   128,
+			want:   image.Pt(128, 43),
+		},
+		{
+			name:   "portrait",
+			width:  100,
+			height: 400,
+			size:   128,
+			want:   image.Pt(32, 128),
+		},
+		{
+			name:   "thin",
+			width:  1000,
+			height: 1,
+			size:   10,
+			want:   image.Pt(10, 1),
+		},
+		{
+			name:   "smaller",
+			width:  50,
+			height: 20,
+			size:   128,
+			want:   image.Pt(50, 20),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			src := image.NewGray(image.Rect(0, 0, tc.width, tc.height))
+			for i := range src.Pix {
+				src.Pix[i] = 200
+			}
+			thumbnail := resizeToFit(src, tc.size)
+			require.Equal(t, tc.want, thumbnail.Bounds().Size())
+
+			// the average of a plain image is its color
+			r, g, b, _ := thumbnail.At(0, 0).RGBA()
+			require.Equal(t, []uint32{200, 200, 200}, []uint32{r >> 8, g >> 8, b >> 8})
+		})
+	}
+}
+
+// newTestImage returns a noisy image of the given format, the noise keeps its encoding large
+func newTestImage(t *testing.T, format string, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	random := rand.New(rand.NewSource(int64(width*height + len(format))))
+	for i := range img.Pix {
+		img.Pix[i] = byte(random.Intn(256))
+	}
+
+	var buffer bytes.Buffer
+	var err error
+	switch format {
+	case "png":
+		err = png.Encode(&buffer, img)
+	case "jpeg":
+		err = jpeg.Encode(&buffer, img, nil)
+	case "gif":
+		err = gif.Encode(&buffer, img, nil)
+	default:
+		t.Fatalf("unknown image format: %s", format)
+	}
+	require.NoError(t, err)
+	return buffer.Bytes()
+}
+
+// newTestWebP returns the header of a WebP image with the given first chunk
+func newTestWebP(t *testing.T, chunk string, width, height int) []byte {
+	data := make([]byte, 10)
+	switch chunk {
+	case "VP8 ":
+		copy(data[3:6], []byte{0x9d, 0x01, 0x2a})
+		binary.LittleEndian.PutUint16(data[6:8], uint16(width))
+		binary.LittleEndian.PutUint16(data[8:10], uint16(height))
+	case "VP8L":
+		data[0] = 0x2f
+		binary.LittleEndian.PutUint32(data[1:5], uint32(width-1)|uint32(height-1)<<14)
+	case "VP8X":
+		data[4], data[5], data[6] = byte(width-1), byte((width-1)>>8), byte((width-1)>>16)
+		data[7], data[8], data[9] = byte(height-1), byte((height-1)>>8), byte((height-1)>>16)
+	default:
+		t.Fatalf("unknown WebP chunk: %s", chunk)
+	}
+
+	header := []byte("RIFF\x00\x00\x00\x00WEBP" + chunk + "\x00\x00\x00\x00")
+	return append(header, data...)
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ImageGCReport lists the garbage found by the image garbage collector
+type ImageGCReport struct {
+	// OrphanBlobs are the keys of the blobs of no image: data, thumbnails and refs of removed images
+	OrphanBlobs []string
+	// MissingData are the IDs of the images whose data is not in the blob store
+	MissingData []string
+	// OrphanImages are the IDs of the images of laptops that don't exist
+	OrphanImages []string
+	// DryRun is true if the garbage was only reported, not removed
+	DryRun bool
+}
+
+// Empty returns true if no garbage was found
+func (report *ImageGCReport) Empty() bool {
+	return len(report.OrphanBlobs) == 0 && len(report.MissingData) == 0 && len(report.OrphanImages) == 0
+}
+
+func (report *ImageGCReport) String() string {
+	action := "removed"
+	if report.DryRun {
+		action = "found (dry run)"
+	}
+	return fmt.Sprintf(
+		"image garbage %s: %d orphan blobs %v, %d images without data %v, %d images of deleted laptops %v",
+		action,
+		len(report.OrphanBlobs), report.OrphanBlobs,
+		len(report.MissingData), report.MissingData,
+		len(report.OrphanImages), report.OrphanImages,
+	)
+}
+
+// CollectGarbage finds the blobs of no image, the images without data and the images of laptops
+// that are not in laptopStore, and removes them unless dryRun. laptopStore must be persistent,
+// the images of the laptops it lost are garbage.
+// The refs of an image being saved are stored before its info, so a ref of no image is garbage
+// only if the previous collection found it too. The garbage is found without the blob mutex,
+// and each blob is checked again before it is removed, so that the images being saved keep their data
+func (store *DiskImageStore) CollectGarbage(laptopStore LaptopStore, dryRun bool) (*ImageGCReport, error) {
+	store.gcMutex.Lock()
+	defer store.gcMutex.Unlock()
+
+	// the refs and the infos of this server are not written while the blobs are listed, the infos are read after them
+	store.blobMutex.Lock()
+	keys, err := store.blobs.List("")
+	store.blobMutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	stored := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		stored[key] = true
+	}
+
+	images, err := store.listInfos()
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[string]bool, len(images)*4)
+	for _, info := range images {
+		used[info.BlobKey] = true
+		used[infoKey(info.ID)] = true
+		used[blobRefKey(info.SHA256, info.ID)] = true
+		used[laptopRefKey(info.LaptopID, info.ID)] = true
+	}
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].ID < images[j].ID
+	})
+
+	report := &ImageGCReport{
+		OrphanBlobs:  []string{},
+		MissingData:  []string{},
+		OrphanImages: []string{},
+		DryRun:       dryRun,
+	}
+	danglingRefs := make(map[string]bool)
+	for _, key := range keys {
+		if !isImageRef(key) || used[key] {
+			continue
+		}
+		danglingRefs[key] = true
+		if !store.danglingRefs[key] && strings.HasPrefix(key, blobRefPrefix) {
+			// the blob of an image being saved
+			used[blobKey(path.Base(path.Dir(key)))] = true
+		}
+	}
+	for _, key := range keys {
+		// the uploads expire on their own
+		if strings.HasPrefix(key, uploadPrefix) {
+			continue
+		}
+		if isImageRef(key) {
+			if danglingRefs[key] && store.danglingRefs[key] {
+				report.OrphanBlobs = append(report.OrphanBlobs, key)
+			}
+			continue
+		}
+		if !used[baseBlobKey(key)] {
+			report.OrphanBlobs = append(report.OrphanBlobs, key)
+		}
+	}
+	store.danglingRefs = danglingRefs
+
+	// the garbage images are removed with their info, and their data if no other image shares it
+	var missingData, orphanImages []*ImageInfo
+	for _, info := range images {
+		if !stored[info.BlobKey] {
+			report.MissingData = append(report.MissingData, info.ID)
+			missingData = append(missingData, info)
+			continue
+		}
+		laptop, err := laptopStore.Find(info.LaptopID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot find laptop: %w", err)
+		}
+		if laptop == nil {
+			report.OrphanImages = append(report.OrphanImages, info.ID)
+			orphanImages = append(orphanImages, info)
+		}
+	}
+	if dryRun {
+		return report, nil
+	}
+
+	for _, info := range missingData {
+		err = store.removeGarbageImage(info, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, info := range orphanImages {
+		err = store.removeGarbageImage(info, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range report.OrphanBlobs {
+		err = store.removeGarbageBlob(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// isImageRef returns true if key is the key of a ref of an image
+func isImageRef(key string) bool {
+	return strings.HasPrefix(key, blobRefPrefix) || strings.HasPrefix(key, laptopRefPrefix)
+}
+
+// removeGarbageImage removes an image found by the garbage collector,
+// an image without data is kept if its data was stored since
+func (store *DiskImageStore) removeGarbageImage(info *ImageInfo, missingData bool) error {
+	store.blobMutex.Lock()
+	defer store.blobMutex.Unlock()
+
+	if missingData {
+		reader, err := store.blobs.Get(info.BlobKey)
+		if err == nil {
+			return reader.Close()
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("cannot open image data: %w", err)
+		}
+	}
+	err := store.delete(info.ID)
+	if errors.Is(err, ErrNotFound) {
+		// removed since it was found
+		return nil
+	}
+	return err
+}
+
+// removeGarbageBlob removes a blob found by the garbage collector,
+// it is kept if an image was saved with it since
+func (store *DiskImageStore) removeGarbageBlob(key string) error {
+	store.blobMutex.Lock()
+	defer store.blobMutex.Unlock()
+
+	if isImageRef(key) {
+		info, err := store.Find(path.Base(key))
+		if err != nil || info != nil {
+			return err
+		}
+		return store.blobs.Delete(key)
+	}
+	if !strings.HasPrefix(key, imageInfoPrefix) {
+		refs, err := store.blobs.List(blobRefKey(path.Base(baseBlobKey(key)), ""))
+		if err != nil || len(refs) > 0 {
+			return err
+		}
+	}
+	return store.blobs.Delete(key)
+}
+
+// baseBlobKey returns the key of the image data of a thumbnail, or key if it is not a thumbnail
+func baseBlobKey(key string) string {
+	if strings.HasPrefix(key, imageInfoPrefix) {
+		return key
+	}
+	// the names of the blobs are checksums, a thumbnail adds its size after a dot
+	name := path.Base(key)
+	if dot := strings.IndexByte(name, '.'); dot >= 0 {
+		return strings.TrimSuffix(key, name[dot:])
+	}
+	return key
+}
+
+// RunGarbageCollector collects the garbage of the store every interval until ctx is done,
+// the reports of non-empty runs are logged
+func (store *DiskImageStore) RunGarbageCollector(ctx context.Context, laptopStore LaptopStore, interval time.Duration, dryRun bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := store.CollectGarbage(laptopStore, dryRun)
+			if err != nil {
+				log.Printf("cannot collect image garbage: %v", err)
+				continue
+			}
+			if !report.Empty() {
+				log.Println(report)
+			}
+		}
+	}
+}
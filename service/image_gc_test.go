@@ -0,0 +1,167 @@
+package service
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"library/v1/sample"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadDiskImageStore(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	store := NewDiskImageStore(folder)
+	photo := newTestImage(t, "png", 20, 10)
+	first, _, err := store.Save("laptop1", ".png", bytes.NewReader(photo))
+	require.NoError(t, err)
+	second, _, err := store.Save("laptop1", "", bytes.NewReader(photo))
+	require.NoError(t, err)
+	firstInfo, err := store.Find(first)
+	require.NoError(t, err)
+
+	// an image being saved when the server stopped
+	leftover, err := os.CreateTemp(folder, tempImagePattern)
+	require.NoError(t, err)
+	leftover.Close()
+
+	loaded, err := LoadDiskImageStore(folder)
+	require.NoError(t, err)
+	require.NoFileExists(t, leftover.Name())
+
+	images, err := loaded.List("laptop1")
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+	require.Equal(t, first, images[0].ID)
+	require.Equal(t, second, images[1].ID)
+	require.True(t, firstInfo.CreatedAt.Equal(images[0].CreatedAt))
+	images[0].CreatedAt = firstInfo.CreatedAt
+	require.Equal(t, firstInfo, images[0])
+
+	// the images still share their data
+	_, deduplicated, err := loaded.Save("laptop2", ".png", bytes.NewReader(photo))
+	require.NoError(t, err)
+	require.True(t, deduplicated)
+	err = loaded.Delete(first)
+	require.NoError(t, err)
+	reader, err := loaded.Open(second)
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(reader)
+	reader.Close()
+	require.NoError(t, err)
+	require.Equal(t, photo, data)
+
+	loaded, err = LoadDiskImageStore(folder)
+	require.NoError(t, err)
+	info, err := loaded.Find(first)
+	require.NoError(t, err)
+	require.Nil(t, info)
+}
+
+func TestDiskImageStoreCollectGarbage(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := NewInMemoryLaptopStore()
+	laptop := sample.NewLaptop()
+	err := laptopStore.Save(laptop)
+	require.NoError(t, err)
+
+	folder := t.TempDir()
+	blobs := NewLocalBlobStore(filepath.Join(folder, "blobs"))
+	store := NewDiskImageStore(folder, WithBlobStore(blobs))
+
+	kept, _, err := store.Save(laptop.GetId(), ".png", bytes.NewReader(newTestImage(t, "png", 300, 100)))
+	require.NoError(t, err)
+	reader, err := store.OpenThumbnail(kept, 128)
+	require.NoError(t, err)
+	reader.Close()
+	orphan, _, err := store.Save("deleted laptop", ".png", bytes.NewReader(newTestImage(t, "png", 20, 10)))
+	require.NoError(t, err)
+	missing, _, err := store.Save(laptop.GetId(), ".gif", bytes.NewReader(newTestImage(t, "gif", 20, 10)))
+	require.NoError(t, err)
+	missingInfo, err := store.Find(missing)
+	require.NoError(t, err)
+	err = blobs.Delete(missingInfo.BlobKey)
+	require.NoError(t, err)
+
+	// the data of images removed while the server was stopped
+	orphanBlobs := []string{"00/11/0011", "00/11/0011.128"}
+	for _, key := range orphanBlobs {
+		err = blobs.Put(key, bytes.NewReader([]byte("garbage")))
+		require.NoError(t, err)
+	}
+	// the ref of an image removed while it was saved, it is garbage once found twice
+	danglingRef := blobRefKey("0022", "removed")
+	err = blobs.Put(danglingRef, bytes.NewReader(nil))
+	require.NoError(t, err)
+	keys, err := blobs.List("")
+	require.NoError(t, err)
+
+	report, err := store.CollectGarbage(laptopStore, true)
+	require.NoError(t, err)
+	require.Equal(t, &ImageGCReport{
+		OrphanBlobs:  orphanBlobs,
+		MissingData:  []string{missing},
+		OrphanImages: []string{orphan},
+		DryRun:       true,
+	}, report)
+	after, err := blobs.List("")
+	require.NoError(t, err)
+	require.Equal(t, keys, after)
+
+	report, err = store.CollectGarbage(laptopStore, false)
+	require.NoError(t, err)
+	require.Equal(t, append([]string{orphanBlobs[0], orphanBlobs[1]}, danglingRef), report.OrphanBlobs)
+	for _, imageID := range []string{orphan, missing} {
+		info, err := store.Find(imageID)
+		require.NoError(t, err)
+		require.Nil(t, info)
+	}
+	keptInfo, err := store.Find(kept)
+	require.NoError(t, err)
+	keys, err = blobs.List("")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		keptInfo.BlobKey,
+		keptInfo.BlobKey + ".128",
+		infoKey(kept),
+		blobRefKey(keptInfo.SHA256, kept),
+		laptopRefKey(laptop.GetId(), kept),
+	}, keys)
+
+	report, err = store.CollectGarbage(laptopStore, false)
+	require.NoError(t, err)
+	require.True(t, report.Empty())
+}
+
+// TestDiskImageStoreRemoveGarbageBlob checks that a blob is kept if an image refers to it since it was found
+func TestDiskImageStoreRemoveGarbageBlob(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	blobs := NewLocalBlobStore(filepath.Join(folder, "blobs"))
+	store := NewDiskImageStore(folder, WithBlobStore(blobs))
+	photo := newTestImage(t, "png", 20, 10)
+	imageID, _, err := store.Save("laptop", ".png", bytes.NewReader(photo))
+	require.NoError(t, err)
+	info, err := store.Find(imageID)
+	require.NoError(t, err)
+
+	for _, key := range []string{info.BlobKey, blobRefKey(info.SHA256, imageID), laptopRefKey("laptop", imageID)} {
+		err = store.removeGarbageBlob(key)
+		require.NoError(t, err)
+		reader, err := blobs.Get(key)
+		require.NoError(t, err, key)
+		reader.Close()
+	}
+
+	// the images without data are kept if it was stored again
+	err = store.removeGarbageImage(info, true)
+	require.NoError(t, err)
+	info, err = store.Find(imageID)
+	require.NoError(t, err)
+	require.NotNil(t, info)
+}
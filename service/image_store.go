@@ -1,68 +1,588 @@
 package service
 
 import (
+	"bufio"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"io"
+	"net/url"
 	"os"
+	"path"
+	"path/filepath"
+	"sort"
 	"sync"
+	"time"
 )
 
 // ImageStore is a interface to store laptop images
 type ImageStore interface {
-	Save(laptopID string, imageType string, imageData bytes.Buffer) (string, error)
+	// Save stores the image of a laptop read from imageData until io.EOF and returns its ID,
+	// nothing is stored if imageData returns another error. It returns an ErrInvalidImage
+	// if the data is not an image of an allowed format, or of the format of a non-empty imageType.
+	// deduplicated is true if the same data was already stored for another image
+	Save(laptopID string, imageType string, imageData io.Reader) (imageID string, deduplicated bool, err error)
+	// Find returns the image with the given ID, or nil if it doesn't exist
+	Find(imageID string) (*ImageInfo, error)
+	// List returns the images of a laptop, the oldest first
+	List(laptopID string) ([]*ImageInfo, error)
+	// Delete removes the image with the given ID, and its data if no other image shares it.
+	// It returns ErrNotFound if the image doesn't exist
+	Delete(imageID string) error
+	// Open returns the data of the image with the given ID, it returns ErrNotFound if it doesn't exist
+	Open(imageID string) (io.ReadCloser, error)
+	// OpenThumbnail returns the data of the image with the given ID resized to fit in a square of size pixels,
+	// in the format of the image. The images that are smaller or cannot be resized are returned as is
+	OpenThumbnail(imageID string, size int) (io.ReadCloser, error)
 }
 
-// DiskImageStore stores images on disk and its info on memory
+// tempImagePattern names the files of the images being saved
+const tempImagePattern = ".upload-*"
+
+// DiskImageStore checks the images on disk and stores their data and their info in a BlobStore.
+// The data of the images is stored once in blobs named by its SHA-256, the images with the same data
+// share the blob. Nothing is kept on memory, so that servers sharing a blob store see the same images:
+// each image has a ref to its blob, and a blob is removed with the last ref to it
 type DiskImageStore struct {
-	mutex       sync.RWMutex
 	imageFolder string
-	image       map[string]*ImageInfo
+	blobs       BlobStore
+	// blobMutex orders the writes of the refs and the infos and the removals of the blobs of this server,
+	// the image data is uploaded without it
+	blobMutex sync.Mutex
+	// gcMutex orders the garbage collections, danglingRefs are the refs of no image found by the last one
+	gcMutex      sync.Mutex
+	danglingRefs map[string]bool
+}
+
+// DiskImageStoreOption configures a DiskImageStore
+type DiskImageStoreOption func(*DiskImageStore)
+
+// WithBlobStore stores the image data in blobs instead of the blobs folder of the image folder
+func WithBlobStore(blobs BlobStore) DiskImageStoreOption {
+	return func(store *DiskImageStore) {
+		store.blobs = blobs
+	}
 }
 
 // ImageInfo contains information of the laptop image
 type ImageInfo struct {
-	LaptopID string
-	Type     string
-	Path     string
+	ID       string `json:"id"`
+	LaptopID string `json:"laptop_id"`
+	Type     string `json:"type"`
+	// Format is the format detected from the image data, such as png or jpeg
+	Format string `json:"format"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	// BlobKey is the key of the image data in the blob store
+	BlobKey string `json:"blob_key"`
+	Size    int64  `json:"size"`
+	// SHA256 is the hex encoded SHA-256 of the image data
+	SHA256    string    `json:"sha256"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
-// NewDiskImageStore returns a new DiskImageStore
-func NewDiskImageStore(imageFolder string) *DiskImageStore {
+// ContentType returns the MIME type of the image
+func (info *ImageInfo) ContentType() string {
+	format := imageFormatByName(info.Format)
+	if format == nil {
+		return "application/octet-stream"
+	}
+	return format.contentType
+}
 
-	return &DiskImageStore{
+// NewDiskImageStore returns a new DiskImageStore, the images being saved are written to imageFolder
+func NewDiskImageStore(imageFolder string, options ...DiskImageStoreOption) *DiskImageStore {
+	store := &DiskImageStore{
 		imageFolder: imageFolder,
-		image:       make(map[string]*ImageInfo),
+		blobs:       NewLocalBlobStore(filepath.Join(imageFolder, "blobs")),
+	}
+	for _, option := range options {
+		option(store)
+	}
+	return store
+}
+
+// LoadDiskImageStore returns a DiskImageStore with the images saved by a previous run,
+// the images that were being saved when it stopped are removed. The refs of the images
+// stored before the images had refs are added
+func LoadDiskImageStore(imageFolder string, options ...DiskImageStoreOption) (*DiskImageStore, error) {
+	err := os.MkdirAll(imageFolder, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create image folder: %w", err)
+	}
+	files, err := filepath.Glob(filepath.Join(imageFolder, tempImagePattern))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		err = os.Remove(file)
+		if err != nil {
+			return nil, fmt.Errorf("cannot remove previous image: %w", err)
+		}
+	}
+
+	store := NewDiskImageStore(imageFolder, options...)
+	infos, err := store.listInfos()
+	if err != nil {
+		return nil, err
+	}
+	refs, err := store.listRefs()
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if refs[info.ID] {
+			continue
+		}
+		err = store.writeRefs(info)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+// The keys of the info of the images and of their refs start with a prefix
+// that is not a hex digit like the checksums of the blobs
+const (
+	imageInfoPrefix = "images/"
+	// blobRefPrefix starts the keys of the refs of the images to their blob
+	blobRefPrefix = "refs/"
+	// laptopRefPrefix starts the keys of the refs of the laptops to their images
+	laptopRefPrefix = "laptops/"
+)
+
+// infoKey returns the key of the info of an image
+func infoKey(imageID string) string {
+	return imageInfoPrefix + imageID + ".json"
+}
+
+// blobRefKey returns the key of the ref of an image to the blob with the given checksum
+func blobRefKey(checksum string, imageID string) string {
+	return blobRefPrefix + checksum + "/" + imageID
+}
+
+// laptopRefKey returns the key of the ref of a laptop to one of its images
+func laptopRefKey(laptopID string, imageID string) string {
+	return laptopRefPrefix + url.PathEscape(laptopID) + "/" + imageID
+}
+
+// listInfos returns the info of every image of the blob store
+func (store *DiskImageStore) listInfos() ([]*ImageInfo, error) {
+	keys, err := store.blobs.List(imageInfoPrefix)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]*ImageInfo, 0, len(keys))
+	for _, key := range keys {
+		info, err := store.readInfo(key)
+		if errors.Is(err, ErrNotFound) {
+			// deleted since the listing
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// listRefs returns the IDs of the images that have a ref to their blob
+func (store *DiskImageStore) listRefs() (map[string]bool, error) {
+	keys, err := store.blobs.List(blobRefPrefix)
+	if err != nil {
+		return nil, err
+	}
+	refs := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		refs[path.Base(key)] = true
+	}
+	return refs, nil
+}
+
+func (store *DiskImageStore) readInfo(key string) (*ImageInfo, error) {
+	reader, err := store.blobs.Get(key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open image info: %w", err)
+	}
+	defer reader.Close()
+
+	info := &ImageInfo{}
+	err = json.NewDecoder(reader).Decode(info)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read image info %s: %w", key, err)
+	}
+	return info, nil
+}
+
+// writeRefs stores the refs of the blob and of the laptop of an image
+func (store *DiskImageStore) writeRefs(info *ImageInfo) error {
+	err := store.blobs.Put(blobRefKey(info.SHA256, info.ID), bytes.NewReader(nil))
+	if err != nil {
+		return fmt.Errorf("cannot store image ref: %w", err)
+	}
+	err = store.blobs.Put(laptopRefKey(info.LaptopID, info.ID), bytes.NewReader(nil))
+	if err != nil {
+		return fmt.Errorf("cannot store image ref: %w", err)
+	}
+	return nil
+}
+
+func (store *DiskImageStore) writeInfo(info *ImageInfo) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("cannot encode image info: %w", err)
+	}
+	err = store.blobs.Put(infoKey(info.ID), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("cannot store image info: %w", err)
 	}
+	return nil
 }
 
-func (store *DiskImageStore) Save(laptopID string, imageType string, imageData bytes.Buffer) (string, error) {
+// blobKey returns the key of the blob with the given checksum,
+// the blobs are spread in directories named by the first bytes of their checksum
+func blobKey(checksum string) string {
+	return checksum[0:2] + "/" + checksum[2:4] + "/" + checksum
+}
+
+// thumbnailKey returns the key of the thumbnail of a blob
+func thumbnailKey(blobKey string, size int) string {
+	return fmt.Sprintf("%s.%d", blobKey, size)
+}
+
+func (store *DiskImageStore) Save(laptopID string, imageType string, imageData io.Reader) (string, bool, error) {
 	// Random generate image ID
 	imageID, err := uuid.NewRandom()
 	if err != nil {
-		return "", fmt.Errorf("cannot generate image id: %w", err)
+		return "", false, fmt.Errorf("cannot generate image id: %w", err)
+	}
+
+	// the format is checked before the data is written
+	buffered := bufio.NewReaderSize(imageData, sniffLength)
+	header, err := buffered.Peek(sniffLength)
+	if err != nil && err != io.EOF {
+		return "", false, fmt.Errorf("cannot read image: %w", err)
+	}
+	format, err := detectImageFormat(imageType, header)
+	if err != nil {
+		return "", false, err
+	}
+	if imageType == "" {
+		imageType = format.extensions[0]
+	}
+
+	// The image is written to a temporary file and checked before it is stored in its blob,
+	// so that a failed upload never leaves a partial image
+	file, err := os.CreateTemp(store.imageFolder, tempImagePattern)
+	if err != nil {
+		return "", false, fmt.Errorf("cannot create image file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), buffered)
+	if err != nil {
+		return "", false, fmt.Errorf("cannot write image to file: %w", err)
+	}
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return "", false, fmt.Errorf("cannot read image file: %w", err)
+	}
+	width, height, err := imageDimensions(format, file)
+	if err != nil {
+		return "", false, err
+	}
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return "", false, fmt.Errorf("cannot read image file: %w", err)
+	}
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	key := blobKey(checksum)
+
+	info := &ImageInfo{
+		ID:        imageID.String(),
+		LaptopID:  laptopID,
+		Type:      imageType,
+		Format:    format.name,
+		Width:     width,
+		Height:    height,
+		BlobKey:   key,
+		Size:      size,
+		SHA256:    checksum,
+		CreatedAt: time.Now(),
+	}
+
+	// The refs are written before the blob is checked, a server deleting the last other image
+	// of the blob keeps it then. The info is written last, the image exists once it is complete
+	store.blobMutex.Lock()
+	deduplicated, err := store.addRefs(info)
+	store.blobMutex.Unlock()
+	if err != nil {
+		return "", false, err
+	}
+
+	// the data is uploaded without the mutex, the other images are saved and deleted meanwhile
+	if !deduplicated {
+		err = store.blobs.Put(key, file)
+		if err != nil {
+			store.blobMutex.Lock()
+			store.release(info)
+			store.blobMutex.Unlock()
+			return "", false, fmt.Errorf("cannot store image data: %w", err)
+		}
+	}
+
+	store.blobMutex.Lock()
+	defer store.blobMutex.Unlock()
+
+	err = store.writeInfo(info)
+	if err != nil {
+		store.release(info)
+		return "", false, err
+	}
+
+	// a server that listed the refs of the blob before the ref of this image was written may have removed it,
+	// or the image whose data was being uploaded when this one was deduplicated failed
+	err = store.restoreBlob(key, file)
+	if err != nil {
+		store.delete(info.ID)
+		return "", false, err
+	}
+	return info.ID, deduplicated, nil
+}
+
+// addRefs writes the refs of an image and returns true if its blob is shared with other images,
+// the refs are removed if it fails. The caller must hold the blob mutex
+func (store *DiskImageStore) addRefs(info *ImageInfo) (bool, error) {
+	err := store.writeRefs(info)
+	if err != nil {
+		store.release(info)
+		return false, err
+	}
+	refs, err := store.blobs.List(blobRefKey(info.SHA256, ""))
+	if err != nil {
+		store.release(info)
+		return false, err
+	}
+	return len(refs) > 1, nil
+}
+
+// restoreBlob stores the data of file under key again if the blob was removed
+func (store *DiskImageStore) restoreBlob(key string, file *os.File) error {
+	reader, err := store.blobs.Get(key)
+	if err == nil {
+		return reader.Close()
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("cannot open image data: %w", err)
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("cannot read image file: %w", err)
+	}
+	err = store.blobs.Put(key, file)
+	if err != nil {
+		return fmt.Errorf("cannot store image data: %w", err)
+	}
+	return nil
+}
+
+func (store *DiskImageStore) Find(imageID string) (*ImageInfo, error) {
+	info, err := store.readInfo(infoKey(imageID))
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (store *DiskImageStore) List(laptopID string) ([]*ImageInfo, error) {
+	keys, err := store.blobs.List(laptopRefKey(laptopID, ""))
+	if err != nil {
+		return nil, err
+	}
+
+	images := []*ImageInfo{}
+	for _, key := range keys {
+		// the ref of an image being saved or deleted has no info
+		info, err := store.Find(path.Base(key))
+		if err != nil {
+			return nil, err
+		}
+		if info != nil {
+			images = append(images, info)
+		}
+	}
+	sort.Slice(images, func(i, j int) bool {
+		if !images[i].CreatedAt.Equal(images[j].CreatedAt) {
+			return images[i].CreatedAt.Before(images[j].CreatedAt)
+		}
+		return images[i].ID < images[j].ID
+	})
+	return images, nil
+}
+
+func (store *DiskImageStore) Delete(imageID string) error {
+	store.blobMutex.Lock()
+	defer store.blobMutex.Unlock()
+	return store.delete(imageID)
+}
+
+// delete removes an image, the caller holds the blob mutex
+func (store *DiskImageStore) delete(imageID string) error {
+	info, err := store.Find(imageID)
+	if err != nil {
+		return err
+	}
+	if info == nil {
+		return ErrNotFound
+	}
+	// without its info, the refs and the data of an image that fails to be removed are found by the garbage collector
+	err = store.blobs.Delete(infoKey(imageID))
+	if err != nil {
+		return fmt.Errorf("cannot remove image info: %w", err)
+	}
+	return store.release(info)
+}
+
+// release removes the refs of an image, and its data if no other image refers to it
+func (store *DiskImageStore) release(info *ImageInfo) error {
+	err := store.blobs.Delete(laptopRefKey(info.LaptopID, info.ID))
+	if err != nil {
+		return fmt.Errorf("cannot remove image ref: %w", err)
+	}
+	err = store.blobs.Delete(blobRefKey(info.SHA256, info.ID))
+	if err != nil {
+		return fmt.Errorf("cannot remove image ref: %w", err)
+	}
+	refs, err := store.blobs.List(blobRefKey(info.SHA256, ""))
+	if err != nil {
+		return err
+	}
+	if len(refs) > 0 {
+		return nil
+	}
+
+	err = store.blobs.Delete(info.BlobKey)
+	if err != nil {
+		return fmt.Errorf("cannot remove image data: %w", err)
+	}
+	thumbnails, err := store.blobs.List(info.BlobKey + ".")
+	if err != nil {
+		return err
+	}
+	for _, thumbnail := range thumbnails {
+		err = store.blobs.Delete(thumbnail)
+		if err != nil {
+			return fmt.Errorf("cannot remove thumbnail: %w", err)
+		}
+	}
+	return nil
+}
+
+func (store *DiskImageStore) Open(imageID string) (io.ReadCloser, error) {
+	info, err := store.Find(imageID)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, ErrNotFound
+	}
+
+	reader, err := store.blobs.Get(info.BlobKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open image data: %w", err)
+	}
+	return reader, nil
+}
+
+func (store *DiskImageStore) OpenThumbnail(imageID string, size int) (io.ReadCloser, error) {
+	info, err := store.Find(imageID)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, ErrNotFound
+	}
+
+	format := imageFormatByName(info.Format)
+	fits := info.Width <= size && info.Height <= size
+	if format == nil || !format.resizable || fits || info.Width*info.Height > maxResizedPixels {
+		return store.Open(imageID)
 	}
 
-	// Create image path
-	imagePath := fmt.Sprintf("%s/%s%s", store.imageFolder, imageID, imageType)
+	// the thumbnails are made on the first request and kept next to their blob
+	key := thumbnailKey(info.BlobKey, size)
+	reader, err := store.blobs.Get(key)
+	if err == nil {
+		return reader, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("cannot open thumbnail: %w", err)
+	}
+
+	err = store.writeThumbnail(info, format, key, size)
+	if err != nil {
+		return nil, err
+	}
+	reader, err = store.blobs.Get(key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open thumbnail: %w", err)
+	}
+	return reader, nil
+}
 
-	// Create image path and Write data into file
-	file, err := os.Create(imagePath)
+// writeThumbnail makes the thumbnail of an image in a temporary file and stores it under key
+func (store *DiskImageStore) writeThumbnail(info *ImageInfo, format *imageFormat, key string, size int) error {
+	blob, err := store.blobs.Get(info.BlobKey)
 	if err != nil {
-		return "", fmt.Errorf("cannot create image file: %w", err)
+		return fmt.Errorf("cannot open image data: %w", err)
 	}
-	_, err = imageData.WriteTo(file)
+	defer blob.Close()
+
+	file, err := os.CreateTemp(store.imageFolder, tempImagePattern)
 	if err != nil {
-		return "", fmt.Errorf("cannot write image to file: %w", err)
+		return fmt.Errorf("cannot create thumbnail file: %w", err)
 	}
+	defer os.Remove(file.Name())
+	defer file.Close()
 
-	store.mutex.RLock()
-	defer store.mutex.RUnlock()
-	store.image[imageID.String()] = &ImageInfo{
-		LaptopID: laptopID,
-		Type:     imageType,
-		Path:     imagePath,
+	buffered := bufio.NewWriter(file)
+	err = writeThumbnail(format, bufio.NewReader(blob), buffered, size)
+	if err == nil {
+		err = buffered.Flush()
+	}
+	if err != nil {
+		return fmt.Errorf("cannot write thumbnail: %w", err)
+	}
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("cannot read thumbnail file: %w", err)
 	}
 
-	return imageID.String(), nil
+	// the thumbnail of an image deleted while it was made would be left without its blob
+	store.blobMutex.Lock()
+	defer store.blobMutex.Unlock()
+	current, err := store.Find(info.ID)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return ErrNotFound
+	}
+	err = store.blobs.Put(key, file)
+	if err != nil {
+		return fmt.Errorf("cannot store thumbnail: %w", err)
+	}
+	return nil
 }
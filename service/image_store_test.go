@@ -0,0 +1,317 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestDiskImageStoreSave(t *testing.T) {
+	t.Parallel()
+
+	errUpload := errors.New("upload canceled")
+	data := newTestImage(t, "png", 40, 30)
+
+	testCases := []struct {
+		name      string
+		imageType string
+		imageData io.Reader
+		err       error
+	}{
+		{
+			name:      "success",
+			imageType: ".png",
+			imageData: bytes.NewReader(data),
+		},
+		{
+			name:      "success_without_type",
+			imageData: bytes.NewReader(data),
+		},
+		{
+			name:      "failure_reader",
+			imageType: ".png",
+			imageData: io.MultiReader(bytes.NewReader(data[:100]), &errorReader{err: errUpload}),
+			err:       errUpload,
+		},
+		{
+			name:      "failure_type_mismatch",
+			imageType: ".gif",
+			imageData: bytes.NewReader(data),
+			err:       ErrInvalidImage,
+		},
+		{
+			name:      "failure_not_image",
+			imageType: ".png",
+			imageData: bytes.NewReader([]byte("image")),
+			err:       ErrInvalidImage,
+		},
+		{
+			name:      "failure_truncated",
+			imageType: ".png",
+			imageData: bytes.NewReader(data[:20]),
+			err:       ErrInvalidImage,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			folder := t.TempDir()
+			store := NewDiskImageStore(folder)
+			imageID, deduplicated, err := store.Save("laptop", tc.imageType, tc.imageData)
+			files, readErr := ioutil.ReadDir(folder)
+			require.NoError(t, readErr)
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				// the partial image is removed
+				require.Empty(t, files)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, deduplicated)
+
+			info, err := store.Find(imageID)
+			require.NoError(t, err)
+			require.EqualValues(t, len(data), info.Size)
+			require.Equal(t, ".png", info.Type)
+			require.Equal(t, "image/png", info.ContentType())
+			require.Equal(t, 40, info.Width)
+			require.Equal(t, 30, info.Height)
+			checksum := sha256.Sum256(data)
+			require.Equal(t, hex.EncodeToString(checksum[:]), info.SHA256)
+			require.Equal(t, info.SHA256[0:2]+"/"+info.SHA256[2:4]+"/"+info.SHA256, info.BlobKey)
+			require.FileExists(t, filepath.Join(folder, "blobs", filepath.FromSlash(info.BlobKey)))
+		})
+	}
+}
+
+func TestDiskImageStoreDeduplication(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	store := NewDiskImageStore(folder)
+	photo := newTestImage(t, "jpeg", 20, 10)
+
+	first, deduplicated, err := store.Save("laptop1", ".jpg", bytes.NewReader(photo))
+	require.NoError(t, err)
+	require.False(t, deduplicated)
+	second, deduplicated, err := store.Save("laptop2", ".jpeg", bytes.NewReader(photo))
+	require.NoError(t, err)
+	require.True(t, deduplicated)
+	other, deduplicated, err := store.Save("laptop2", ".png", bytes.NewReader(newTestImage(t, "png", 20, 10)))
+	require.NoError(t, err)
+	require.False(t, deduplicated)
+
+	firstInfo, err := store.Find(first)
+	require.NoError(t, err)
+	secondInfo, err := store.Find(second)
+	require.NoError(t, err)
+	require.Equal(t, firstInfo.BlobKey, secondInfo.BlobKey)
+	blobPath := filepath.Join(folder, "blobs", filepath.FromSlash(secondInfo.BlobKey))
+	require.Equal(t, "image/jpeg", secondInfo.ContentType())
+
+	// the blob is kept until its last image is deleted
+	err = store.Delete(first)
+	require.NoError(t, err)
+	require.FileExists(t, blobPath)
+	reader, err := store.Open(second)
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(reader)
+	reader.Close()
+	require.NoError(t, err)
+	require.Equal(t, photo, data)
+
+	err = store.Delete(second)
+	require.NoError(t, err)
+	require.NoFileExists(t, blobPath)
+
+	// the same data is stored again after its blob was removed
+	_, deduplicated, err = store.Save("laptop1", ".jpg", bytes.NewReader(photo))
+	require.NoError(t, err)
+	require.False(t, deduplicated)
+	require.FileExists(t, blobPath)
+
+	images, err := store.List("laptop2")
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	require.Equal(t, other, images[0].ID)
+}
+
+// TestDiskImageStoreSharedBlobs checks that two stores sharing a blob store see the images of each other
+func TestDiskImageStoreSharedBlobs(t *testing.T) {
+	t.Parallel()
+
+	blobs := NewLocalBlobStore(t.TempDir())
+	first := NewDiskImageStore(t.TempDir(), WithBlobStore(blobs))
+	second := NewDiskImageStore(t.TempDir(), WithBlobStore(blobs))
+	photo := newTestImage(t, "png", 20, 10)
+
+	firstID, deduplicated, err := first.Save("laptop", ".png", bytes.NewReader(photo))
+	require.NoError(t, err)
+	require.False(t, deduplicated)
+	info, err := second.Find(firstID)
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	images, err := second.List("laptop")
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	require.Equal(t, info, images[0])
+
+	secondID, deduplicated, err := second.Save("laptop", ".png", bytes.NewReader(photo))
+	require.NoError(t, err)
+	require.True(t, deduplicated)
+
+	// the blob is kept while an image of any store refers to it
+	err = second.Delete(firstID)
+	require.NoError(t, err)
+	info, err = first.Find(firstID)
+	require.NoError(t, err)
+	require.Nil(t, info)
+	reader, err := first.Open(secondID)
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(reader)
+	reader.Close()
+	require.NoError(t, err)
+	require.Equal(t, photo, data)
+
+	err = first.Delete(secondID)
+	require.NoError(t, err)
+	keys, err := blobs.List("")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
+
+// TestDiskImageStoreSlowUpload checks that the images are saved and deleted while the data of another one is uploaded
+func TestDiskImageStoreSlowUpload(t *testing.T) {
+	t.Parallel()
+
+	photo := newTestImage(t, "png", 20, 10)
+	checksum := sha256.Sum256(photo)
+	blobs := &blockingBlobStore{
+		BlobStore: NewLocalBlobStore(t.TempDir()),
+		key:       blobKey(hex.EncodeToString(checksum[:])),
+		started:   make(chan struct{}),
+		release:   make(chan struct{}),
+	}
+	store := NewDiskImageStore(t.TempDir(), WithBlobStore(blobs))
+
+	type saveResult struct {
+		id  string
+		err error
+	}
+	slow := make(chan saveResult)
+	go func() {
+		id, _, err := store.Save("laptop1", ".png", bytes.NewReader(photo))
+		slow <- saveResult{id: id, err: err}
+	}()
+	<-blobs.started
+
+	other, deduplicated, err := store.Save("laptop2", ".png", bytes.NewReader(newTestImage(t, "png", 30, 10)))
+	require.NoError(t, err)
+	require.False(t, deduplicated)
+	require.NoError(t, store.Delete(other))
+
+	// the image with the same data stores the blob that is not uploaded yet
+	shared, deduplicated, err := store.Save("laptop2", ".png", bytes.NewReader(photo))
+	require.NoError(t, err)
+	require.True(t, deduplicated)
+	reader, err := store.Open(shared)
+	require.NoError(t, err)
+	reader.Close()
+
+	close(blobs.release)
+	result := <-slow
+	require.NoError(t, result.err)
+	require.NoError(t, store.Delete(shared))
+	reader, err = store.Open(result.id)
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(reader)
+	reader.Close()
+	require.NoError(t, err)
+	require.Equal(t, photo, data)
+}
+
+func TestDiskImageStoreThumbnail(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	store := NewDiskImageStore(folder)
+	data := newTestImage(t, "png", 300, 100)
+	imageID, _, err := store.Save("laptop", ".png", bytes.NewReader(data))
+	require.NoError(t, err)
+	info, err := store.Find(imageID)
+	require.NoError(t, err)
+
+	readThumbnail := func(size int) []byte {
+		reader, err := store.OpenThumbnail(imageID, size)
+		require.NoError(t, err)
+		defer reader.Close()
+		thumbnail, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		return thumbnail
+	}
+
+	thumbnail := readThumbnail(128)
+	config, err := png.DecodeConfig(bytes.NewReader(thumbnail))
+	require.NoError(t, err)
+	require.Equal(t, 128, config.Width)
+	require.Equal(t, 43, config.Height)
+
+	// the thumbnail is made once
+	thumbnailPath := filepath.Join(folder, "blobs", filepath.FromSlash(info.BlobKey)+".128")
+	require.FileExists(t, thumbnailPath)
+	require.Equal(t, thumbnail, readThumbnail(128))
+
+	// the image fits in a large thumbnail
+	require.Equal(t, data, readThumbnail(1024))
+
+	_, err = store.OpenThumbnail("unknown", 128)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = store.Delete(imageID)
+	require.NoError(t, err)
+	require.NoFileExists(t, thumbnailPath)
+}
+
+type errorReader struct {
+	err error
+}
+
+func (reader *errorReader) Read(p []byte) (int, error) {
+	return 0, reader.err
+}
+
+// blockingBlobStore blocks the first put of key until release is closed
+type blockingBlobStore struct {
+	BlobStore
+	key     string
+	once    sync.Once
+	started chan struct{}
+	release chan struct{}
+}
+
+func (store *blockingBlobStore) Put(key string, data io.Reader) error {
+	if key == store.key {
+		blocked := false
+		store.once.Do(func() {
+			blocked = true
+		})
+		if blocked {
+			close(store.started)
+			<-store.release
+		}
+	}
+	return store.BlobStore.Put(key, data)
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"library/v1/pb"
+	"library/v1/query"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// ErrInvalidFacet is returned when a facet definition cannot be computed
+var ErrInvalidFacet = errors.New("invalid facet")
+
+// defaultFacets are the facets of the storefront filter sidebar
+func defaultFacets() []*pb.Facet {
+	priceRange := func(from, to float64) *pb.RangeFacet_Range {
+		r := &pb.RangeFacet_Range{}
+		if from > 0 {
+			r.From = wrapperspb.Double(from)
+		}
+		if to > 0 {
+			r.To = wrapperspb.Double(to)
+		}
+		return r
+	}
+
+	return []*pb.Facet{
+		{Field: "brand", Type: &pb.Facet_Terms{Terms: &pb.TermsFacet{}}},
+		{Field: "cpu.brand", Type: &pb.Facet_Terms{Terms: &pb.TermsFacet{}}},
+		{Field: "ram", Type: &pb.Facet_Terms{Terms: &pb.TermsFacet{}}},
+		{Field: "price_usd", Type: &pb.Facet_Range{Range: &pb.RangeFacet{
+			Ranges: []*pb.RangeFacet_Range{
+				priceRange(0, 1000),
+				priceRange(1000, 1500),
+				priceRange(1500, 2000),
+				priceRange(2000, 3000),
+				priceRange(3000, 0),
+			},
+		}}},
+		{Field: "screen.panel", Type: &pb.Facet_Terms{Terms: &pb.TermsFacet{}}},
+	}
+}
+
+// facetCounter counts the laptops in the buckets of facets
+type facetCounter struct {
+	total  uint64
+	facets []*facetCount
+}
+
+// facetCount is the bucket counts of one facet
+type facetCount struct {
+	facet     *pb.Facet
+	name      string
+	path      *query.Path
+	terms     map[string]uint64
+	ranges    []uint64
+	histogram map[float64]uint64
+}
+
+// newFacetCounter checks the facets against the laptop fields and returns their counter
+func newFacetCounter(facets []*pb.Facet) (*facetCounter, error) {
+	counter := &facetCounter{}
+	for _, facet := range facets {
+		name := facet.GetName()
+		if name == "" {
+			name = facet.GetField()
+		}
+
+		path, err := query.ParsePath(laptopDescriptor, facet.GetField(), laptopQueryOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: field %v", ErrInvalidFacet, name, err)
+		}
+
+		count := &facetCount{facet: facet, name: name, path: path}
+		switch facet.GetType().(type) {
+		case *pb.Facet_Range:
+			if len(facet.GetRange().GetRanges()) == 0 {
+				return nil, fmt.Errorf("%w %s: range facet has no ranges", ErrInvalidFacet, name)
+			}
+			count.ranges = make([]uint64, len(facet.GetRange().GetRanges()))
+		case *pb.Facet_Histogram:
+			interval := facet.GetHistogram().GetInterval()
+			if interval <= 0 || math.IsInf(interval, 0) || math.IsNaN(interval) {
+				return nil, fmt.Errorf("%w %s: histogram interval must be positive", ErrInvalidFacet, name)
+			}
+			count.histogram = make(map[float64]uint64)
+		default:
+			count.terms = make(map[string]uint64)
+		}
+
+		if count.terms != nil && !isTermsField(path) {
+			return nil, fmt.Errorf("%w %s: cannot count the terms of field %s", ErrInvalidFacet, name, path)
+		}
+		if count.terms == nil && !isNumberField(path) {
+			return nil, fmt.Errorf("%w %s: field %s is not a number", ErrInvalidFacet, name, path)
+		}
+		counter.facets = append(counter.facets, count)
+	}
+	return counter, nil
+}
+
+// add counts a laptop in the buckets of its values,
+// a laptop is counted once per bucket even if a repeated field has several values in it
+func (counter *facetCounter) add(laptop *pb.Laptop) {
+	counter.total++
+
+	for _, count := range counter.facets {
+		switch {
+		case count.terms != nil:
+			keys := make(map[string]bool)
+			count.path.Range(laptop, func(value protoreflect.Value) {
+				keys[termKey(count.path, value)] = true
+			})
+			for key := range keys {
+				count.terms[key]++
+			}
+		case count.ranges != nil:
+			matched := make([]bool, len(count.ranges))
+			count.path.Range(laptop, func(value protoreflect.Value) {
+				number := numberValue(count.path, value)
+				for i, r := range count.facet.GetRange().GetRanges() {
+					if inRange(r, number) {
+						matched[i] = true
+					}
+				}
+			})
+			for i := range matched {
+				if matched[i] {
+					count.ranges[i]++
+				}
+			}
+		default:
+			interval := count.facet.GetHistogram().GetInterval()
+			keys := make(map[float64]bool)
+			count.path.Range(laptop, func(value protoreflect.Value) {
+				keys[math.Floor(numberValue(count.path, value)/interval)*interval] = true
+			})
+			for key := range keys {
+				count.histogram[key]++
+			}
+		}
+	}
+}
+
+// results returns the buckets of the facets in the order they were defined
+func (counter *facetCounter) results() []*pb.FacetResult {
+	results := make([]*pb.FacetResult, len(counter.facets))
+	for i, count := range counter.facets {
+		result := &pb.FacetResult{Name: count.name}
+		switch {
+		case count.terms != nil:
+			for key, n := range count.terms {
+				result.Buckets = append(result.Buckets, &pb.FacetBucket{Key: key, Count: n})
+			}
+			sort.Slice(result.Buckets, func(i, j int) bool {
+				a, b := result.Buckets[i], result.Buckets[j]
+				if a.Count != b.Count {
+					return a.Count > b.Count
+				}
+				return a.Key < b.Key
+			})
+			size := int(count.facet.GetTerms().GetSize())
+			if size > 0 && len(result.Buckets) > size {
+				for _, bucket := range result.Buckets[size:] {
+					result.OtherCount += bucket.Count
+				}
+				result.Buckets = result.Buckets[:size]
+			}
+		case count.ranges != nil:
+			for i, r := range count.facet.GetRange().GetRanges() {
+				key := r.GetKey()
+				if key == "" {
+					key = rangeKey(r)
+				}
+				result.Buckets = append(result.Buckets, &pb.FacetBucket{
+					Key:   key,
+					Count: count.ranges[i],
+					From:  r.GetFrom(),
+					To:    r.GetTo(),
+				})
+			}
+		default:
+			interval := count.facet.GetHistogram().GetInterval()
+			for from, n := range count.histogram {
+				result.Buckets = append(result.Buckets, &pb.FacetBucket{
+					Key:   formatNumber(from),
+					Count: n,
+					From:  wrapperspb.Double(from),
+					To:    wrapperspb.Double(from + interval),
+				})
+			}
+			sort.Slice(result.Buckets, func(i, j int) bool {
+				return result.Buckets[i].From.GetValue() < result.Buckets[j].From.GetValue()
+			})
+		}
+		results[i] = result
+	}
+	return results
+}
+
+func isTermsField(path *query.Path) bool {
+	switch path.Field().Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return path.IsMemory()
+	case protoreflect.BytesKind:
+		return false
+	default:
+		return true
+	}
+}
+
+func isNumberField(path *query.Path) bool {
+	switch path.Field().Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return path.IsMemory()
+	case protoreflect.BoolKind, protoreflect.EnumKind, protoreflect.StringKind, protoreflect.BytesKind:
+		return false
+	default:
+		return true
+	}
+}
+
+// termKey returns the bucket key of a value, enums are named and memory sizes are written like 16GB
+func termKey(path *query.Path, value protoreflect.Value) string {
+	field := path.Field()
+	switch field.Kind() {
+	case protoreflect.EnumKind:
+		enumValue := field.Enum().Values().ByNumber(value.Enum())
+		if enumValue == nil {
+			return strconv.Itoa(int(value.Enum()))
+		}
+		return string(enumValue.Name())
+	case protoreflect.StringKind:
+		return value.String()
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(value.Bool())
+	case protoreflect.MessageKind:
+		return formatMemory(query.MemoryBits(value))
+	default:
+		return formatNumber(numberValue(path, value))
+	}
+}
+
+// numberValue returns a numeric value of the path, memory sizes are in bytes
+func numberValue(path *query.Path, value protoreflect.Value) float64 {
+	switch path.Field().Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return float64(value.Int())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return float64(value.Uint())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return value.Float()
+	case protoreflect.MessageKind:
+		return float64(query.MemoryBits(value) / 8)
+	default:
+		return 0
+	}
+}
+
+func inRange(r *pb.RangeFacet_Range, number float64) bool {
+	if r.GetFrom() != nil && number < r.GetFrom().GetValue() {
+		return false
+	}
+	if r.GetTo() != nil && number >= r.GetTo().GetValue() {
+		return false
+	}
+	return true
+}
+
+// rangeKey returns the default key of a range, such as 1000-1500 or 3000-*
+func rangeKey(r *pb.RangeFacet_Range) string {
+	from, to := "*", "*"
+	if r.GetFrom() != nil {
+		from = formatNumber(r.GetFrom().GetValue())
+	}
+	if r.GetTo() != nil {
+		to = formatNumber(r.GetTo().GetValue())
+	}
+	return from + "-" + to
+}
+
+func formatNumber(number float64) string {
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+// memoryUnits are the units of formatMemory, the largest first
+var memoryUnits = []struct {
+	name string
+	bits uint64
+}{
+	{"TB", 8 << 40},
+	{"GB", 8 << 30},
+	{"MB", 8 << 20},
+	{"KB", 8 << 10},
+	{"B", 8},
+}
+
+// formatMemory writes a memory size in the largest unit that divides it
+func formatMemory(bits uint64) string {
+	if bits == 0 {
+		return "0B"
+	}
+	for _, unit := range memoryUnits {
+		if bits%unit.bits == 0 {
+			return strconv.FormatUint(bits/unit.bits, 10) + unit.name
+		}
+	}
+	return strconv.FormatUint(bits, 10) + "bit"
+}
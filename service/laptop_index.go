@@ -0,0 +1,307 @@
+package service
+
+import (
+	"library/v1/pb"
+	"math"
+	"sort"
+	"strings"
+)
+
+// indexEntry is the key of a laptop in a sortedIndex,
+// entries are ordered by number, then text, then laptop ID
+type indexEntry struct {
+	number float64
+	text   string
+	id     string
+}
+
+func (a indexEntry) less(b indexEntry) bool {
+	if a.number != b.number {
+		return a.number < b.number
+	}
+	if a.text != b.text {
+		return a.text < b.text
+	}
+	return a.id < b.id
+}
+
+// maxChunkSize is the largest number of entries of a chunk of a sortedIndex
+const maxChunkSize = 512
+
+// sortedIndex is a secondary index of the laptops of a store sorted by one key.
+// The entries are split in sorted chunks, a change moves the entries of one chunk
+// instead of every entry, and the size of a range is counted from the chunk sizes
+type sortedIndex struct {
+	key    func(laptop *pb.Laptop) indexEntry
+	chunks [][]indexEntry
+}
+
+// indexPosition is the position of an entry in a sortedIndex, chunk is len(chunks) after the last entry
+type indexPosition struct {
+	chunk  int
+	offset int
+}
+
+// indexRange is the entries of a sortedIndex from a position until another
+type indexRange struct {
+	index    *sortedIndex
+	from, to indexPosition
+}
+
+func newNumberIndex(key func(laptop *pb.Laptop) float64) *sortedIndex {
+	return &sortedIndex{
+		key: func(laptop *pb.Laptop) indexEntry {
+			return indexEntry{number: key(laptop), id: laptop.GetId()}
+		},
+	}
+}
+
+func newStringIndex(key func(laptop *pb.Laptop) string) *sortedIndex {
+	return &sortedIndex{
+		key: func(laptop *pb.Laptop) indexEntry {
+			return indexEntry{text: key(laptop), id: laptop.GetId()}
+		},
+	}
+}
+
+// insert adds the laptop to the index, a full chunk is split in two
+func (index *sortedIndex) insert(laptop *pb.Laptop) {
+	entry := index.key(laptop)
+	position := index.find(entry)
+	if position.chunk == len(index.chunks) {
+		if position.chunk == 0 {
+			index.chunks = [][]indexEntry{{entry}}
+			return
+		}
+		// the entry is after every entry, it is added to the last chunk
+		position.chunk--
+		position.offset = len(index.chunks[position.chunk])
+	}
+
+	chunk := append(index.chunks[position.chunk], indexEntry{})
+	copy(chunk[position.offset+1:], chunk[position.offset:])
+	chunk[position.offset] = entry
+	index.chunks[position.chunk] = chunk
+
+	if len(chunk) > maxChunkSize {
+		half := len(chunk) / 2
+		second := append([]indexEntry(nil), chunk[half:]...)
+		index.chunks[position.chunk] = chunk[:half:half]
+		index.chunks = append(index.chunks, nil)
+		copy(index.chunks[position.chunk+2:], index.chunks[position.chunk+1:])
+		index.chunks[position.chunk+1] = second
+	}
+}
+
+// remove deletes the laptop from the index, laptop must be the indexed version
+func (index *sortedIndex) remove(laptop *pb.Laptop) {
+	entry := index.key(laptop)
+	position := index.find(entry)
+	if position.chunk == len(index.chunks) || index.chunks[position.chunk][position.offset] != entry {
+		return
+	}
+
+	chunk := index.chunks[position.chunk]
+	chunk = append(chunk[:position.offset], chunk[position.offset+1:]...)
+	index.chunks[position.chunk] = chunk
+
+	// a small chunk is merged with the next one, so removals don't leave many tiny chunks
+	next := position.chunk + 1
+	if len(chunk) >= maxChunkSize/4 || next == len(index.chunks) && len(chunk) > 0 {
+		return
+	}
+	if next < len(index.chunks) && len(chunk)+len(index.chunks[next]) <= maxChunkSize {
+		index.chunks[position.chunk] = append(chunk, index.chunks[next]...)
+		index.chunks = append(index.chunks[:next], index.chunks[next+1:]...)
+		return
+	}
+	if len(chunk) == 0 {
+		index.chunks = append(index.chunks[:position.chunk], index.chunks[next:]...)
+	}
+}
+
+// find returns the position of the first entry that isn't less than entry
+func (index *sortedIndex) find(entry indexEntry) indexPosition {
+	return index.search(func(other indexEntry) bool {
+		return !other.less(entry)
+	})
+}
+
+// search returns the position of the first entry for which after is true,
+// after must be false then true along the entries
+func (index *sortedIndex) search(after func(entry indexEntry) bool) indexPosition {
+	chunk := sort.Search(len(index.chunks), func(i int) bool {
+		entries := index.chunks[i]
+		return after(entries[len(entries)-1])
+	})
+	if chunk == len(index.chunks) {
+		return indexPosition{chunk: chunk}
+	}
+	entries := index.chunks[chunk]
+	offset := sort.Search(len(entries), func(i int) bool {
+		return after(entries[i])
+	})
+	return indexPosition{chunk: chunk, offset: offset}
+}
+
+// numberRange returns the entries of a number index with min <= number <= max
+func (index *sortedIndex) numberRange(min, max float64) indexRange {
+	return indexRange{
+		index: index,
+		from: index.search(func(entry indexEntry) bool {
+			return entry.number >= min
+		}),
+		to: index.search(func(entry indexEntry) bool {
+			return entry.number > max
+		}),
+	}
+}
+
+// textRange returns the entries of a text index equal to text
+func (index *sortedIndex) textRange(text string) indexRange {
+	return indexRange{
+		index: index,
+		from: index.search(func(entry indexEntry) bool {
+			return entry.text >= text
+		}),
+		to: index.search(func(entry indexEntry) bool {
+			return entry.text > text
+		}),
+	}
+}
+
+// size returns the number of entries of the range without reading them
+func (r indexRange) size() int {
+	if r.from.chunk > r.to.chunk || (r.from.chunk == r.to.chunk && r.from.offset >= r.to.offset) {
+		return 0
+	}
+	if r.from.chunk == r.to.chunk {
+		return r.to.offset - r.from.offset
+	}
+	size := len(r.index.chunks[r.from.chunk]) - r.from.offset + r.to.offset
+	for _, chunk := range r.index.chunks[r.from.chunk+1 : r.to.chunk] {
+		size += len(chunk)
+	}
+	return size
+}
+
+// appendTo appends the entries of the range to entries
+func (r indexRange) appendTo(entries []indexEntry) []indexEntry {
+	if r.size() == 0 {
+		return entries
+	}
+	if r.from.chunk == r.to.chunk {
+		return append(entries, r.index.chunks[r.from.chunk][r.from.offset:r.to.offset]...)
+	}
+	entries = append(entries, r.index.chunks[r.from.chunk][r.from.offset:]...)
+	for _, chunk := range r.index.chunks[r.from.chunk+1 : r.to.chunk] {
+		entries = append(entries, chunk...)
+	}
+	if r.to.chunk < len(r.index.chunks) {
+		entries = append(entries, r.index.chunks[r.to.chunk][:r.to.offset]...)
+	}
+	return entries
+}
+
+// laptopIndexes are the secondary indexes of InMemoryLaptopStore
+type laptopIndexes struct {
+	price *sortedIndex
+	cores *sortedIndex
+	ghz   *sortedIndex
+	ram   *sortedIndex
+	brand *sortedIndex
+}
+
+func newLaptopIndexes() *laptopIndexes {
+	return &laptopIndexes{
+		price: newNumberIndex(func(laptop *pb.Laptop) float64 {
+			return laptop.GetPriceUsd()
+		}),
+		cores: newNumberIndex(func(laptop *pb.Laptop) float64 {
+			return float64(laptop.GetCpu().GetNumCores())
+		}),
+		ghz: newNumberIndex(func(laptop *pb.Laptop) float64 {
+			return laptop.GetCpu().GetMinGhz()
+		}),
+		ram: newNumberIndex(func(laptop *pb.Laptop) float64 {
+			return float64(toBit(laptop.GetRam()))
+		}),
+		brand: newStringIndex(func(laptop *pb.Laptop) string {
+			return strings.ToLower(laptop.GetBrand())
+		}),
+	}
+}
+
+func (indexes *laptopIndexes) all() []*sortedIndex {
+	return []*sortedIndex{indexes.price, indexes.cores, indexes.ghz, indexes.ram, indexes.brand}
+}
+
+func (indexes *laptopIndexes) insert(laptop *pb.Laptop) {
+	for _, index := range indexes.all() {
+		index.insert(laptop)
+	}
+}
+
+func (indexes *laptopIndexes) remove(laptop *pb.Laptop) {
+	for _, index := range indexes.all() {
+		index.remove(laptop)
+	}
+}
+
+// candidates returns the index entries of the laptops that may match filter,
+// read from the index with the fewest entries in range.
+// It returns false when no index applies to the filter and every laptop is a candidate.
+func (indexes *laptopIndexes) candidates(filter *pb.Filter) ([]indexEntry, bool) {
+	// each item is the ranges of an index, several ranges for the brands
+	var ranges [][]indexRange
+
+	if filter.GetMaxPriceUsd() > 0 || filter.GetMinPriceUsd() > 0 {
+		max := filter.GetMaxPriceUsd()
+		if max <= 0 {
+			max = math.MaxFloat64
+		}
+		ranges = append(ranges, []indexRange{indexes.price.numberRange(filter.GetMinPriceUsd(), max)})
+	}
+	if filter.GetMinCpuCores() > 0 {
+		ranges = append(ranges, []indexRange{indexes.cores.numberRange(float64(filter.GetMinCpuCores()), math.MaxFloat64)})
+	}
+	if filter.GetMinCpuGhz() > 0 {
+		ranges = append(ranges, []indexRange{indexes.ghz.numberRange(filter.GetMinCpuGhz(), math.MaxFloat64)})
+	}
+	if ram := toBit(filter.GetMinRam()); ram > 0 {
+		ranges = append(ranges, []indexRange{indexes.ram.numberRange(float64(ram), math.MaxFloat64)})
+	}
+	if len(filter.GetBrands()) > 0 {
+		var brandRanges []indexRange
+		seen := make(map[string]bool)
+		for _, brand := range filter.GetBrands() {
+			brand = strings.ToLower(brand)
+			if !seen[brand] {
+				seen[brand] = true
+				brandRanges = append(brandRanges, indexes.brand.textRange(brand))
+			}
+		}
+		ranges = append(ranges, brandRanges)
+	}
+
+	if len(ranges) == 0 {
+		return nil, false
+	}
+
+	best, bestSize := 0, -1
+	for i, indexRanges := range ranges {
+		size := 0
+		for _, r := range indexRanges {
+			size += r.size()
+		}
+		if bestSize < 0 || size < bestSize {
+			best, bestSize = i, size
+		}
+	}
+
+	entries := make([]indexEntry, 0, bestSize)
+	for _, r := range ranges[best] {
+		entries = r.appendTo(entries)
+	}
+	return entries, true
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"library/v1/pb"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// TestSortedIndex checks the chunks of an index against a sorted slice of its entries
+func TestSortedIndex(t *testing.T) {
+	t.Parallel()
+
+	index := newNumberIndex(func(laptop *pb.Laptop) float64 {
+		return laptop.GetPriceUsd()
+	})
+	random := rand.New(rand.NewSource(1))
+	laptops := make([]*pb.Laptop, 5*maxChunkSize)
+	for i := range laptops {
+		laptops[i] = &pb.Laptop{Id: fmt.Sprintf("laptop-%04d", i), PriceUsd: float64(random.Intn(100))}
+		index.insert(laptops[i])
+	}
+	// the laptops of the first chunks are removed, their chunks are removed when empty
+	removed := make(map[string]bool)
+	for _, laptop := range laptops {
+		if laptop.GetPriceUsd() < 30 || random.Intn(3) == 0 {
+			index.remove(laptop)
+			removed[laptop.GetId()] = true
+		}
+	}
+	index.remove(&pb.Laptop{Id: "unknown", PriceUsd: 50})
+
+	var expected []indexEntry
+	for _, laptop := range laptops {
+		if !removed[laptop.GetId()] {
+			expected = append(expected, index.key(laptop))
+		}
+	}
+	sort.Slice(expected, func(i, j int) bool {
+		return expected[i].less(expected[j])
+	})
+
+	all := index.numberRange(0, 1000)
+	require.Equal(t, len(expected), all.size())
+	require.Equal(t, expected, all.appendTo(nil))
+	for i, chunk := range index.chunks {
+		require.NotEmpty(t, chunk)
+		require.LessOrEqual(t, len(chunk), maxChunkSize)
+		if i > 0 {
+			require.True(t, index.chunks[i-1][len(index.chunks[i-1])-1].less(chunk[0]))
+		}
+	}
+	// the small chunks left by the removals are merged
+	require.LessOrEqual(t, len(index.chunks), 2*len(expected)/maxChunkSize+1)
+
+	testCases := []struct {
+		min, max float64
+	}{
+		{min: 0, max: 29},
+		{min: 40, max: 40},
+		{min: 35.5, max: 70.5},
+		{min: 90, max: 1000},
+		{min: 80, max: 20},
+	}
+	for _, tc := range testCases {
+		var inRange []indexEntry
+		for _, entry := range expected {
+			if entry.number >= tc.min && entry.number <= tc.max {
+				inRange = append(inRange, entry)
+			}
+		}
+
+		r := index.numberRange(tc.min, tc.max)
+		require.Equal(t, len(inRange), r.size(), "range [%v, %v]", tc.min, tc.max)
+		require.Equal(t, inRange, r.appendTo(nil), "range [%v, %v]", tc.min, tc.max)
+	}
+}
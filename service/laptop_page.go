@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"library/v1/pb"
+	"library/v1/query"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultPageSize is the page size of ListLaptops when the request doesn't set one
+	DefaultPageSize = 50
+	// MaxPageSize is the largest page size of ListLaptops
+	MaxPageSize = 1000
+)
+
+// pageCursor is the position after the last laptop of a page,
+// it is sent to the client as an opaque page token
+type pageCursor struct {
+	Field      pb.Order_Field `json:"f"`
+	Descending bool           `json:"d"`
+	Key        float64        `json:"k"`
+	ID         string         `json:"i"`
+}
+
+// sortedLaptop is a laptop with the value of its sort key
+type sortedLaptop struct {
+	laptop *pb.Laptop
+	key    float64
+}
+
+// before reports whether a laptop with key and id is sorted at or before the cursor
+func (cursor *pageCursor) before(key float64, id string) bool {
+	if key != cursor.Key {
+		if cursor.Descending {
+			return key > cursor.Key
+		}
+		return key < cursor.Key
+	}
+	return id <= cursor.ID
+}
+
+func encodePageToken(cursor *pageCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal page token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodePageToken(token string, order *pb.Order) (*pageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token")
+	}
+	cursor := &pageCursor{}
+	err = json.Unmarshal(data, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token")
+	}
+	if cursor.Field != order.GetField() || cursor.Descending != order.GetDescending() {
+		return nil, fmt.Errorf("page token doesn't belong to the requested order")
+	}
+	return cursor, nil
+}
+
+// laptopDescriptor is the message type laptop queries are checked against
+var laptopDescriptor = (&pb.Laptop{}).ProtoReflect().Descriptor()
+
+// laptopQueryOptions compare the memory sizes with literals such as 16GB, and the brands without case
+var laptopQueryOptions = []query.Option{
+	query.WithMemory((&pb.Memory{}).ProtoReflect().Descriptor()),
+	query.WithFoldCase(
+		laptopDescriptor.Fields().ByName("brand").FullName(),
+		(&pb.CPU{}).ProtoReflect().Descriptor().Fields().ByName("brand").FullName(),
+		(&pb.GPU{}).ProtoReflect().Descriptor().Fields().ByName("brand").FullName(),
+	),
+}
+
+// laptopSearch is the search of a SearchLaptop or ListLaptops request
+type laptopSearch struct {
+	filter *pb.Filter
+	query  *query.Query
+	text   string
+	order  *pb.Order
+	cursor *pageCursor
+}
+
+// newLaptopSearch checks the search fields of a request.
+// A query replaces the filter, so a request cannot have both,
+// and a search with text is sorted by relevance unless it has an order.
+func newLaptopSearch(filter *pb.Filter, source string, text string, order *pb.Order, pageToken string) (*laptopSearch, error) {
+	search := &laptopSearch{
+		filter: filter,
+		text:   strings.TrimSpace(text),
+		order:  order,
+	}
+
+	if strings.TrimSpace(source) != "" {
+		if proto.Size(filter) > 0 {
+			return nil, fmt.Errorf("filter and query cannot be used together")
+		}
+		q, err := query.Compile(laptopDescriptor, source, laptopQueryOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid query: %w", err)
+		}
+		search.query = q
+	}
+
+	switch {
+	case search.text == "" && order.GetField() == pb.Order_RELEVANCE:
+		return nil, fmt.Errorf("relevance order requires text")
+	case search.text != "" && order.GetField() == pb.Order_UNKNOWN:
+		search.order = &pb.Order{Field: pb.Order_RELEVANCE, Descending: true}
+	}
+
+	cursor, err := decodePageToken(pageToken, search.order)
+	if err != nil {
+		return nil, err
+	}
+	search.cursor = cursor
+	return search, nil
+}
+
+// ratingStatsChunk is the number of laptops of each BatchStats call of a search sorted by rating
+const ratingStatsChunk = 500
+
+// sortKey returns the value a laptop is sorted by, the average ratings are set by averageRatings
+func sortKey(field pb.Order_Field, laptop *pb.Laptop) (float64, error) {
+	switch field {
+	case pb.Order_UNKNOWN, pb.Order_AVERAGE_RATING:
+		return 0, nil
+	case pb.Order_PRICE:
+		return laptop.GetPriceUsd(), nil
+	case pb.Order_CPU_GHZ:
+		return laptop.GetCpu().GetMinGhz(), nil
+	case pb.Order_RAM:
+		return float64(toBit(laptop.GetRam())), nil
+	case pb.Order_RELEASE_YEAR:
+		return float64(laptop.GetReleaseYear()), nil
+	default:
+		return 0, fmt.Errorf("unknown order field: %v", field)
+	}
+}
+
+// averageRatings sets the keys of laptops to their average ratings. The ratings are read
+// once per search, so a search sorts every laptop by the same snapshot of the ratings
+func (server *LaptopServer) averageRatings(laptops []*sortedLaptop) error {
+	if server.ratingStore == nil {
+		return nil
+	}
+
+	for start := 0; start < len(laptops); start += ratingStatsChunk {
+		end := start + ratingStatsChunk
+		if end > len(laptops) {
+			end = len(laptops)
+		}
+		ids := make([]string, 0, end-start)
+		for _, laptop := range laptops[start:end] {
+			ids = append(ids, laptop.laptop.GetId())
+		}
+
+		stats, err := server.ratingStore.BatchStats(ids)
+		if err != nil {
+			return err
+		}
+		for _, laptop := range laptops[start:end] {
+			if laptopStats := stats[laptop.laptop.GetId()]; laptopStats != nil {
+				laptop.key = laptopStats.TotalAverage()
+			}
+		}
+	}
+	return nil
+}
+
+// searchPage returns the laptops of one page of a search sorted by its order,
+// and the token of the next page if there are more laptops.
+// The page starts after the cursor position, so laptops saved between two
+// calls never move a laptop from a page the client has not read yet to one it has.
+// The cursor keeps the sort key of the last laptop of the previous page, a laptop
+// whose key changed since, like a new price or average rating, may move across it
+func (server *LaptopServer) searchPage(ctx context.Context, search *laptopSearch, pageSize int) ([]*pb.Laptop, string, error) {
+	order := search.order
+	cursor := search.cursor
+	// the average ratings are read after the search, the laptops are compared to the cursor then
+	ratingOrder := order.GetField() == pb.Order_AVERAGE_RATING
+
+	var laptops []*sortedLaptop
+	found := func(laptop *pb.Laptop, score float64) error {
+		if search.query != nil && !search.query.Match(laptop) {
+			return nil
+		}
+
+		key := score
+		if order.GetField() != pb.Order_RELEVANCE {
+			var err error
+			key, err = sortKey(order.GetField(), laptop)
+			if err != nil {
+				return err
+			}
+		}
+		if !ratingOrder && cursor != nil && cursor.before(key, laptop.GetId()) {
+			return nil
+		}
+		laptops = append(laptops, &sortedLaptop{laptop: laptop, key: key})
+		return nil
+	}
+
+	err := server.search(ctx, search, found)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if ratingOrder {
+		err = server.averageRatings(laptops)
+		if err != nil {
+			return nil, "", err
+		}
+		if cursor != nil {
+			after := laptops[:0]
+			for _, laptop := range laptops {
+				if !cursor.before(laptop.key, laptop.laptop.GetId()) {
+					after = append(after, laptop)
+				}
+			}
+			laptops = after
+		}
+	}
+
+	sort.Slice(laptops, func(i, j int) bool {
+		a, b := laptops[i], laptops[j]
+		if a.key != b.key {
+			if order.GetDescending() {
+				return a.key > b.key
+			}
+			return a.key < b.key
+		}
+		return a.laptop.GetId() < b.laptop.GetId()
+	})
+
+	nextPageToken := ""
+	if pageSize > 0 && len(laptops) > pageSize {
+		laptops = laptops[:pageSize]
+		last := laptops[pageSize-1]
+		nextPageToken, err = encodePageToken(&pageCursor{
+			Field:      order.GetField(),
+			Descending: order.GetDescending(),
+			Key:        last.key,
+			ID:         last.laptop.GetId(),
+		})
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	page := make([]*pb.Laptop, len(laptops))
+	for i, laptop := range laptops {
+		page[i] = laptop.laptop
+	}
+	return page, nextPageToken, nil
+}
+
+// search calls found with the laptops of the store that match the filter and the text of a search,
+// and their relevance score for a text search
+func (server *LaptopServer) search(ctx context.Context, search *laptopSearch, found func(laptop *pb.Laptop, score float64) error) error {
+	if search.text != "" {
+		return server.laptopStore.SearchText(ctx, search.filter, search.text, found)
+	}
+	return server.laptopStore.Search(ctx, search.filter, func(laptop *pb.Laptop) error {
+		return found(laptop, 0)
+	})
+}
+
+// streamable reports whether the laptops of a search are sent as they are found,
+// a search without order nor page is not sorted
+func (search *laptopSearch) streamable(pageSize int) bool {
+	return pageSize == 0 && search.text == "" && search.order.GetField() == pb.Order_UNKNOWN && search.cursor == nil
+}
@@ -3,32 +3,85 @@ package service
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"io"
+	"io/ioutil"
 	"library/v1/pb"
 	"log"
+	"strings"
+	"time"
 )
 
+// MaxImageSize is the default largest size of an uploaded image
 const MaxImageSize = 1 << 20
 
+// ImageChunkSize is the size of the chunks of a downloaded image
+const ImageChunkSize = 64 << 10
+
+// MaxBatchSize is the largest number of laptops of a BatchCreateLaptops request
+const MaxBatchSize = 10000
+
+// MaxBatchRatings is the largest number of laptops of a BatchGetRatings request
+const MaxBatchRatings = 100
+
+// WatchProgressEvents is the number of changes skipped by the filter of a watch
+// after which their resume token is sent, well below the history of the change feeds
+const WatchProgressEvents = 256
+
+// WatchProgressInterval is the period of the resume token of the changes skipped by the filter of a watch
+const WatchProgressInterval = 10 * time.Second
+
 type LaptopServer struct {
 	laptopStore                         LaptopStore
 	imageStore                          ImageStore
 	ratingStore                         RatingStore
+	uploadStore                         UploadStore
+	maxImageSize                        int64
 	pb.UnimplementedLaptopServiceServer // 必须嵌入以具有向前兼容的实现
 }
 
+// LaptopServerOption configures a LaptopServer
+type LaptopServerOption func(server *LaptopServer)
+
+// WithMaxImageSize sets the largest size of an uploaded image, MaxImageSize by default
+func WithMaxImageSize(size int64) LaptopServerOption {
+	return func(server *LaptopServer) {
+		server.maxImageSize = size
+	}
+}
+
+// WithUploadStore enables the resumable uploads, their data is kept by store until they are committed
+func WithUploadStore(store UploadStore) LaptopServerOption {
+	return func(server *LaptopServer) {
+		server.uploadStore = store
+	}
+}
+
 // NewLaptopServer create *LaptopServer
-func NewLaptopServer(laptopStore LaptopStore, imageStore ImageStore, ratingStore RatingStore) *LaptopServer {
-	return &LaptopServer{
-		laptopStore: laptopStore,
-		imageStore:  imageStore,
-		ratingStore: ratingStore,
+func NewLaptopServer(
+	laptopStore LaptopStore,
+	imageStore ImageStore,
+	ratingStore RatingStore,
+	options ...LaptopServerOption,
+) *LaptopServer {
+	server := &LaptopServer{
+		laptopStore:  laptopStore,
+		imageStore:   imageStore,
+		ratingStore:  ratingStore,
+		maxImageSize: MaxImageSize,
 	}
+	for _, option := range options {
+		option(server)
+	}
+	return server
 }
 
 // CreateLaptop creae Laptop and save in the store
@@ -37,22 +90,15 @@ func (server *LaptopServer) CreateLaptop(ctx context.Context, req *pb.CreateLapt
 	laptop := req.GetLaptop()
 	log.Printf("receive a create-laptop request with id: %s", laptop.Id)
 
+	err := ValidateLaptop(laptop)
+	if err != nil {
+		return nil, logError(invalidLaptopError(err, "laptop"))
+	}
+
 	// laptop ID check or generate
-	// if ID exists, it need checked
-	// or ID not exists, it need generate
-	if len(laptop.Id) > 0 {
-		// check if it's a valid uuid
-		_, err := uuid.Parse(laptop.Id)
-		if err != nil {
-			return nil, status.Error(codes.InvalidArgument, "cannot save laptop to the store")
-		}
-	} else {
-		//id, err := uuid.NewUUID()  // version 1 uuid
-		id, err := uuid.NewRandom() // version 4 uuid
-		if err != nil {
-			return nil, fmt.Errorf("cannot generate a new laptop ID: %v", err)
-		}
-		laptop.Id = id.String()
+	err = assignLaptopID(laptop)
+	if err != nil {
+		return nil, err
 	}
 
 	// todo something
@@ -73,7 +119,7 @@ func (server *LaptopServer) CreateLaptop(ctx context.Context, req *pb.CreateLapt
 	// Save the laptop to store
 	// example, the laptop is saved in memory-store
 	// Normally it is saved in DB
-	err := server.laptopStore.Save(laptop)
+	err = server.laptopStore.Save(laptop)
 	if err != nil {
 		code := codes.Internal
 		if errors.Is(err, ErrAlreadyExists) {
@@ -82,44 +128,443 @@ func (server *LaptopServer) CreateLaptop(ctx context.Context, req *pb.CreateLapt
 		return nil, status.Error(code, "cannot save laptop to the store")
 	}
 	log.Printf("saved laptop wiht id: %s", laptop.Id)
+	sendRevisionHeader(ctx, laptop.Revision)
 
 	// Return response
 	res := &pb.CreateLaptopResponse{
-		Id: laptop.Id,
+		Id:       laptop.Id,
+		Revision: laptop.Revision,
+	}
+	return res, nil
+}
+
+// assignLaptopID checks the ID of a new laptop, or generates one if it is empty
+func assignLaptopID(laptop *pb.Laptop) error {
+	// if ID exists, it need checked
+	// or ID not exists, it need generate
+	if len(laptop.Id) > 0 {
+		// check if it's a valid uuid
+		_, err := uuid.Parse(laptop.Id)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "laptop ID is not a valid UUID: %v", err)
+		}
+		return nil
+	}
+
+	//id, err := uuid.NewUUID()  // version 1 uuid
+	id, err := uuid.NewRandom() // version 4 uuid
+	if err != nil {
+		return status.Errorf(codes.Internal, "cannot generate a new laptop ID: %v", err)
+	}
+	laptop.Id = id.String()
+	return nil
+}
+
+// BatchCreateLaptops is client stream RPC to create many laptops,
+// it reports the result of each laptop instead of failing on the first error
+func (server *LaptopServer) BatchCreateLaptops(stream pb.LaptopService_BatchCreateLaptopsServer) error {
+	var laptops []*pb.Laptop
+	atomic := false
+	for first := true; ; first = false {
+		if err := contextError(stream.Context()); err != nil {
+			return err
+		}
+
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return logError(status.Errorf(codes.Unknown, "cannot receive laptops: %v", err))
+		}
+		if first {
+			atomic = req.GetAtomic()
+		}
+
+		laptops = append(laptops, req.GetLaptops()...)
+		if len(laptops) > MaxBatchSize {
+			return logError(status.Errorf(codes.InvalidArgument, "batch is larger than %d laptops", MaxBatchSize))
+		}
+	}
+	log.Printf("receive a batch-create-laptops request with %d laptops, atomic: %t", len(laptops), atomic)
+
+	results := make([]*pb.BatchCreateLaptopsResponse_Result, len(laptops))
+	valid := make([]*pb.Laptop, 0, len(laptops))
+	for i, laptop := range laptops {
+		results[i] = &pb.BatchCreateLaptopsResponse_Result{Index: uint32(i)}
+		err := ValidateLaptop(laptop)
+		if err != nil {
+			results[i].Status = status.Convert(invalidLaptopError(err, fmt.Sprintf("laptops[%d]", i))).Proto()
+			continue
+		}
+		err = assignLaptopID(laptop)
+		if err != nil {
+			results[i].Status = status.Convert(err).Proto()
+			continue
+		}
+		results[i].Id = laptop.Id
+		valid = append(valid, laptop)
+	}
+
+	created := 0
+	if atomic {
+		var err error
+		created, err = server.saveBatch(laptops, results, len(valid) == len(laptops))
+		if err != nil {
+			return err
+		}
+	} else {
+		for i, laptop := range laptops {
+			if results[i].Status != nil {
+				continue
+			}
+			err := server.laptopStore.Save(laptop)
+			if err != nil {
+				results[i].Status = status.Newf(storeErrorCode(err), "cannot save laptop: %v", err).Proto()
+				continue
+			}
+			created++
+		}
+	}
+
+	res := &pb.BatchCreateLaptopsResponse{
+		Results:      results,
+		CreatedCount: uint32(created),
+	}
+	for i, result := range results {
+		if result.Status == nil {
+			result.Status = status.New(codes.OK, "").Proto()
+			result.Revision = laptops[i].Revision
+		}
+	}
+	log.Printf("created %d laptops of a batch of %d", created, len(laptops))
+	return stream.SendAndClose(res)
+}
+
+// saveBatch saves all the laptops of an atomic batch if they are all valid,
+// the results of the laptops that are not saved because of another one are set to ABORTED
+func (server *LaptopServer) saveBatch(laptops []*pb.Laptop, results []*pb.BatchCreateLaptopsResponse_Result, valid bool) (int, error) {
+	abort := func() {
+		for _, result := range results {
+			if result.Status == nil {
+				result.Status = status.New(codes.Aborted, "another laptop of the batch cannot be saved").Proto()
+			}
+		}
+	}
+
+	if !valid {
+		abort()
+		return 0, nil
+	}
+
+	err := server.laptopStore.SaveAll(laptops)
+	if err != nil {
+		var saveErr *SaveError
+		if !errors.As(err, &saveErr) {
+			return 0, logError(status.Errorf(codes.Internal, "cannot save laptops: %v", err))
+		}
+		results[saveErr.Index].Status = status.Newf(storeErrorCode(err), "cannot save laptop: %v", saveErr.Err).Proto()
+		abort()
+		return 0, nil
+	}
+	return len(laptops), nil
+}
+
+// GetLaptop returns the laptop with the given ID
+func (server *LaptopServer) GetLaptop(ctx context.Context, req *pb.GetLaptopRequest) (*pb.GetLaptopResponse, error) {
+	id := req.GetId()
+	log.Printf("receive a get-laptop request with id: %s", id)
+
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	laptop, err := server.laptopStore.Find(id)
+	if err != nil {
+		return nil, logError(status.Errorf(codes.Internal, "cannot find laptop: %v", err))
+	}
+	if laptop == nil {
+		return nil, logError(status.Errorf(codes.NotFound, "laptop[%s] doesn't exists", id))
+	}
+	sendRevisionHeader(ctx, laptop.Revision)
+
+	res := &pb.GetLaptopResponse{
+		Laptop: laptop,
+	}
+	return res, nil
+}
+
+// UpdateLaptop changes the fields of a stored laptop named by the update mask
+func (server *LaptopServer) UpdateLaptop(ctx context.Context, req *pb.UpdateLaptopRequest) (*pb.UpdateLaptopResponse, error) {
+	laptop := req.GetLaptop()
+	if laptop == nil {
+		return nil, logError(status.Error(codes.InvalidArgument, "laptop is not provided"))
+	}
+	log.Printf("receive an update-laptop request with id: %s, mask: %v", laptop.GetId(), req.GetUpdateMask().GetPaths())
+
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	revision, err := expectedRevision(ctx, req.GetExpectedRevision())
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	found, err := server.laptopStore.Find(laptop.GetId())
+	if err != nil {
+		return nil, logError(status.Errorf(codes.Internal, "cannot find laptop: %v", err))
+	}
+	if found == nil {
+		return nil, logError(status.Errorf(codes.NotFound, "laptop[%s] doesn't exists", laptop.GetId()))
+	}
+
+	err = applyFieldMask(found, laptop, req.GetUpdateMask())
+	if err != nil {
+		return nil, logError(status.Errorf(codes.InvalidArgument, "cannot apply update mask: %v", err))
+	}
+	err = ValidateLaptop(found)
+	if err != nil {
+		return nil, logError(invalidLaptopError(err, "laptop"))
+	}
+	found.UpdatedAt = ptypes.TimestampNow()
+
+	// Without an expected revision the update still must not overwrite
+	// a change made after the laptop was read above
+	if revision == 0 {
+		revision = found.Revision
+	}
+	err = server.laptopStore.Update(found, revision)
+	if err != nil {
+		return nil, logError(status.Errorf(storeErrorCode(err), "cannot update laptop: %v", err))
+	}
+	log.Printf("updated laptop with id: %s, revision: %d", found.Id, found.Revision)
+	sendRevisionHeader(ctx, found.Revision)
+
+	res := &pb.UpdateLaptopResponse{
+		Laptop: found,
+	}
+	return res, nil
+}
+
+// DeleteLaptop removes the laptop with the given ID from the store
+func (server *LaptopServer) DeleteLaptop(ctx context.Context, req *pb.DeleteLaptopRequest) (*pb.DeleteLaptopResponse, error) {
+	id := req.GetId()
+	log.Printf("receive a delete-laptop request with id: %s", id)
+
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	revision, err := expectedRevision(ctx, req.GetExpectedRevision())
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	err = server.laptopStore.Delete(id, revision)
+	if err != nil {
+		return nil, logError(status.Errorf(storeErrorCode(err), "cannot delete laptop: %v", err))
+	}
+	log.Printf("deleted laptop with id: %s", id)
+
+	res := &pb.DeleteLaptopResponse{
+		Id: id,
 	}
 	return res, nil
 }
 
+// SearchLaptop is server stream RPC to send the laptops that match the filter
 func (server *LaptopServer) SearchLaptop(req *pb.SearchLaptopRequest, stream pb.LaptopService_SearchLaptopServer) error {
 	// Get Filter
 	filter := req.GetFilter()
-	log.Printf("receive a search-laptop request with filter: %v", filter)
-
-	// according filter to search laptop and callback func
-	err := server.laptopStore.Search(
-		stream.Context(),
-		filter,
-		func(laptop *pb.Laptop) error {
-			// Construct pb.SearchLaptopResponse
-			res := &pb.SearchLaptopResponse{Laptop: laptop}
-
-			// Use stream method to send it
-			err := stream.Send(res)
-			if err != nil {
-				return err
+	log.Printf("receive a search-laptop request with filter: %v, query: %q, text: %q, order: %v",
+		filter, req.GetQuery(), req.GetText(), req.GetOrderBy())
+
+	search, err := newLaptopSearch(filter, req.GetQuery(), req.GetText(), req.GetOrderBy(), req.GetPageToken())
+	if err != nil {
+		return logError(status.Errorf(codes.InvalidArgument, "cannot search laptop: %v", err))
+	}
+
+	ctx := stream.Context()
+	if search.streamable(int(req.GetPageSize())) {
+		err = server.search(ctx, search, func(laptop *pb.Laptop, score float64) error {
+			if search.query != nil && !search.query.Match(laptop) {
+				return nil
 			}
-			log.Printf("sent laptop with id: %s", laptop.Id)
-			return nil
-		},
-	)
+			return sendLaptop(stream, laptop, "")
+		})
+		return searchError(ctx, err)
+	}
 
+	// according filter to search laptop
+	laptops, nextPageToken, err := server.searchPage(ctx, search, int(req.GetPageSize()))
 	if err != nil {
-		return status.Errorf(codes.Internal, "unexpected error: %v", err)
+		return searchError(ctx, err)
+	}
+
+	for i, laptop := range laptops {
+		token := ""
+		if i == len(laptops)-1 {
+			token = nextPageToken
+		}
+		err = sendLaptop(stream, laptop, token)
+		if err != nil {
+			return searchError(ctx, err)
+		}
 	}
 
 	return nil
 }
 
+// sendLaptop sends a laptop found by SearchLaptop
+func sendLaptop(stream pb.LaptopService_SearchLaptopServer, laptop *pb.Laptop, nextPageToken string) error {
+	// Construct pb.SearchLaptopResponse
+	res := &pb.SearchLaptopResponse{Laptop: laptop, NextPageToken: nextPageToken}
+
+	// Use stream method to send it
+	err := stream.Send(res)
+	if err != nil {
+		return err
+	}
+	log.Printf("sent laptop with id: %s", laptop.Id)
+	return nil
+}
+
+// searchError returns the status of a failed search, the status of the context if it is done
+func searchError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+	return logError(status.Errorf(codes.Internal, "cannot search laptop: %v", err))
+}
+
+// ListLaptops returns one page of the laptops that match the filter
+func (server *LaptopServer) ListLaptops(ctx context.Context, req *pb.ListLaptopsRequest) (*pb.ListLaptopsResponse, error) {
+	filter := req.GetFilter()
+	log.Printf("receive a list-laptops request with filter: %v, query: %q, text: %q, order: %v",
+		filter, req.GetQuery(), req.GetText(), req.GetOrderBy())
+
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	search, err := newLaptopSearch(filter, req.GetQuery(), req.GetText(), req.GetOrderBy(), req.GetPageToken())
+	if err != nil {
+		return nil, logError(status.Errorf(codes.InvalidArgument, "cannot list laptops: %v", err))
+	}
+
+	laptops, nextPageToken, err := server.searchPage(ctx, search, pageSize)
+	if err != nil {
+		return nil, searchError(ctx, err)
+	}
+
+	res := &pb.ListLaptopsResponse{
+		Laptops:       laptops,
+		NextPageToken: nextPageToken,
+	}
+	return res, nil
+}
+
+// WatchLaptops is server stream RPC to send the changes of the laptops that match the filter,
+// a watcher that falls behind is disconnected and can resume from its last resume token.
+// The changes skipped by the filter are acknowledged by PROGRESS responses,
+// so the resume token of a selective watcher doesn't expire.
+func (server *LaptopServer) WatchLaptops(req *pb.WatchLaptopsRequest, stream pb.LaptopService_WatchLaptopsServer) error {
+	filter := req.GetFilter()
+	log.Printf("receive a watch-laptops request with filter: %v, resume token: %q", filter, req.GetResumeToken())
+
+	watcher, err := server.laptopStore.Watch(req.GetResumeToken())
+	if err != nil {
+		return logError(status.Errorf(storeErrorCode(err), "cannot watch laptops: %v", err))
+	}
+	defer watcher.Close()
+
+	ticker := time.NewTicker(WatchProgressInterval)
+	defer ticker.Stop()
+
+	skipped := 0 // changes skipped since the last response
+	skippedToken := ""
+	ctx := stream.Context()
+	for {
+		var res *pb.WatchLaptopsResponse
+		select {
+		case <-ctx.Done():
+			return contextError(ctx)
+		case <-ticker.C:
+			if skipped == 0 {
+				continue
+			}
+			res = &pb.WatchLaptopsResponse{
+				Type:        pb.WatchLaptopsResponse_PROGRESS,
+				ResumeToken: skippedToken,
+			}
+		case event, ok := <-watcher.Events():
+			if !ok {
+				return logError(status.Errorf(
+					storeErrorCode(watcher.Err()),
+					"cannot watch laptops: %v, resume from the last received token", watcher.Err(),
+				))
+			}
+			if isQualified(filter, event.Laptop) {
+				res = &pb.WatchLaptopsResponse{
+					Type:        event.Type,
+					Laptop:      event.Laptop,
+					ResumeToken: event.ResumeToken,
+				}
+				break
+			}
+
+			skipped++
+			skippedToken = event.ResumeToken
+			if skipped < WatchProgressEvents {
+				continue
+			}
+			res = &pb.WatchLaptopsResponse{
+				Type:        pb.WatchLaptopsResponse_PROGRESS,
+				ResumeToken: skippedToken,
+			}
+		}
+
+		skipped = 0
+		err := stream.Send(res)
+		if err != nil {
+			return logError(status.Errorf(codes.Unavailable, "cannot send laptop change: %v", err))
+		}
+	}
+}
+
+// AggregateLaptops counts the laptops that match the filter in the buckets of the facets
+func (server *LaptopServer) AggregateLaptops(ctx context.Context, req *pb.AggregateLaptopsRequest) (*pb.AggregateLaptopsResponse, error) {
+	filter := req.GetFilter()
+	log.Printf("receive an aggregate-laptops request with filter: %v, %d facets", filter, len(req.GetFacets()))
+
+	facets := req.GetFacets()
+	if len(facets) == 0 {
+		facets = defaultFacets()
+	}
+
+	total, results, err := server.laptopStore.Aggregate(ctx, filter, facets)
+	if err != nil {
+		if err := contextError(ctx); err != nil {
+			return nil, err
+		}
+		return nil, logError(status.Errorf(storeErrorCode(err), "cannot aggregate laptops: %v", err))
+	}
+
+	res := &pb.AggregateLaptopsResponse{
+		Total:  total,
+		Facets: results,
+	}
+	return res, nil
+}
+
 // UploadImage is client stream RPC to upload laptop image
 func (server *LaptopServer) UploadImage(stream pb.LaptopService_UploadImageServer) error {
 	req, err := stream.Recv()
@@ -132,6 +577,10 @@ func (server *LaptopServer) UploadImage(stream pb.LaptopService_UploadImageServe
 	imageType := req.GetInfo().GetImageType()
 	log.Printf("reveive a image upload request for laptop[%s] with image type %s", laptopId, imageType)
 
+	if err := checkImageType(imageType); err != nil {
+		return err
+	}
+
 	// Checked laptop if exists
 	laptop, err := server.laptopStore.Find(laptopId)
 	if err != nil {
@@ -141,23 +590,85 @@ func (server *LaptopServer) UploadImage(stream pb.LaptopService_UploadImageServe
 		return logError(status.Errorf(codes.NotFound, "laptop[%s] doesn't exists", laptopId))
 	}
 
-	// Get image data, stores into image store
-	imageData := bytes.Buffer{}
-	imageSize := 0
+	// The chunks are written to the store while they are received, a failed
+	// or canceled upload closes the pipe with its error and the store discards the image
+	reader, writer := io.Pipe()
+	saved := make(chan savedImage, 1)
+	go func() {
+		imageID, deduplicated, err := server.imageStore.Save(laptopId, imageType, reader)
+		// stops the receiving of the chunks if the store fails before the end of the image
+		reader.CloseWithError(err)
+		saved <- savedImage{id: imageID, deduplicated: deduplicated, err: err}
+	}()
+
+	imageSize, err := server.receiveImageChunks(stream, writer)
+	if err != nil {
+		writer.CloseWithError(err)
+		<-saved
+		return err
+	}
+	writer.Close()
+
+	result := <-saved
+	if result.err != nil {
+		return logError(status.Errorf(storeErrorCode(result.err), "cannot save image to the store: %v", result.err))
+	}
+	imageID := result.id
+
+	res := &pb.UploadImageResponse{
+		Id:           imageID,
+		Size:         uint32(imageSize),
+		Deduplicated: result.deduplicated,
+	}
+
+	err = stream.SendAndClose(res)
+	if err != nil {
+		return logError(status.Errorf(codes.Unknown, "cannot send response: %v", err))
+	}
+
+	log.Printf("save image with laptop imageID: %s , size: %d", imageID, imageSize)
+	return nil
+}
+
+// checkImageType checks that the image type is the extension of a file name of an allowed image format,
+// an empty type is detected from the image data
+func checkImageType(imageType string) error {
+	if imageType == "" {
+		return nil
+	}
+	if !strings.HasPrefix(imageType, ".") || strings.ContainsAny(imageType[1:], `./\`) {
+		return logError(status.Errorf(codes.InvalidArgument, "image type is not a file extension: %q", imageType))
+	}
+	if imageFormatByType(imageType) == nil {
+		return logError(status.Errorf(codes.InvalidArgument, "image type is not allowed: %q", imageType))
+	}
+	return nil
+}
+
+// savedImage is the result of ImageStore.Save
+type savedImage struct {
+	id           string
+	deduplicated bool
+	err          error
+}
+
+// receiveImageChunks writes the chunks of an uploaded image to writer and returns the image size
+func (server *LaptopServer) receiveImageChunks(stream pb.LaptopService_UploadImageServer, writer io.Writer) (int64, error) {
+	var imageSize int64
 	for {
 		log.Println("wait receive more data")
 
-		if err = contextError(stream.Context()); err != nil {
-			return err
+		if err := contextError(stream.Context()); err != nil {
+			return 0, err
 		}
 
 		req, err := stream.Recv()
 		if err == io.EOF {
 			log.Println("no more data")
-			break
+			return imageSize, nil
 		}
 		if err != nil {
-			return logError(status.Errorf(codes.Unknown, "cannot receive chunk data: %v", err))
+			return 0, logError(status.Errorf(codes.Unknown, "cannot receive chunk data: %v", err))
 		}
 
 		// Start receive chunk data
@@ -165,38 +676,414 @@ func (server *LaptopServer) UploadImage(stream pb.LaptopService_UploadImageServe
 		size := len(chunkData)
 		log.Println("chunk with seize: ", size)
 
-		imageSize += size
+		imageSize += int64(size)
 
-		if imageSize > MaxImageSize {
-			return logError(status.Errorf(codes.InvalidArgument, "image is too large: [%d > %d]", imageSize, MaxImageSize))
+		if imageSize > server.maxImageSize {
+			return 0, logError(status.Errorf(codes.InvalidArgument, "image is too large: [%d > %d]", imageSize, server.maxImageSize))
 		}
 
-		_, err = imageData.Write(chunkData)
+		_, err = writer.Write(chunkData)
 		if err != nil {
-			return logError(status.Errorf(codes.Internal, "data cannot write: %v", err))
+			return 0, logError(status.Errorf(storeErrorCode(err), "cannot save image to the store: %v", err))
 		}
 	}
+}
+
+// InitiateUpload starts a resumable upload of a laptop image
+func (server *LaptopServer) InitiateUpload(ctx context.Context, req *pb.InitiateUploadRequest) (*pb.InitiateUploadResponse, error) {
+	laptopID := req.GetInfo().GetLaptopId()
+	imageType := req.GetInfo().GetImageType()
+	size := int64(req.GetSize())
+	log.Printf("receive an initiate-upload request for laptop[%s] with image type %s, size: %d", laptopID, imageType, size)
+
+	if server.uploadStore == nil {
+		return nil, logError(status.Error(codes.Unimplemented, "resumable uploads are not enabled"))
+	}
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if err := checkImageType(imageType); err != nil {
+		return nil, err
+	}
+	if size > server.maxImageSize {
+		return nil, logError(status.Errorf(codes.InvalidArgument, "image is too large: [%d > %d]", size, server.maxImageSize))
+	}
+
+	laptop, err := server.laptopStore.Find(laptopID)
+	if err != nil {
+		return nil, logError(status.Errorf(codes.Internal, "cannot find laptop: %v", err))
+	}
+	if laptop == nil {
+		return nil, logError(status.Errorf(codes.NotFound, "laptop[%s] doesn't exists", laptopID))
+	}
 
-	imageID, err := server.imageStore.Save(laptopId, imageType, imageData)
+	upload, err := server.uploadStore.Create(laptopID, imageType, size)
 	if err != nil {
-		return logError(status.Errorf(codes.Internal, "cannot save image to the store: %v", err))
+		return nil, logError(status.Errorf(codes.Internal, "cannot create upload: %v", err))
 	}
+	log.Printf("created upload with id: %s", upload.ID)
 
-	res := &pb.UploadImageResponse{
-		Id:   imageID,
-		Size: uint32(imageSize),
+	res := &pb.InitiateUploadResponse{
+		UploadId: upload.ID,
 	}
+	return res, nil
+}
 
-	err = stream.SendAndClose(res)
+// UploadChunks is client stream RPC to send the chunks of a resumable upload,
+// a dropped stream is resumed by a new one from the committed offset
+func (server *LaptopServer) UploadChunks(stream pb.LaptopService_UploadChunksServer) error {
+	if server.uploadStore == nil {
+		return logError(status.Error(codes.Unimplemented, "resumable uploads are not enabled"))
+	}
+
+	var upload *UploadInfo
+	for {
+		if err := contextError(stream.Context()); err != nil {
+			return err
+		}
+
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return logError(status.Errorf(codes.Unknown, "cannot receive chunk data: %v", err))
+		}
+
+		uploadID := req.GetUploadId()
+		if upload == nil {
+			upload, err = server.findUpload(uploadID)
+			if err != nil {
+				return err
+			}
+			log.Printf("receive chunks of upload[%s] from offset %d", upload.ID, req.GetOffset())
+		} else if uploadID != "" && uploadID != upload.ID {
+			return logError(status.Errorf(codes.InvalidArgument, "chunks of upload[%s] are sent to upload[%s]", uploadID, upload.ID))
+		}
+
+		chunkData := req.GetChunkData()
+		end := int64(req.GetOffset()) + int64(len(chunkData))
+		if end > server.maxImageSize {
+			return logError(status.Errorf(codes.InvalidArgument, "image is too large: [%d > %d]", end, server.maxImageSize))
+		}
+		if upload.Size > 0 && end > upload.Size {
+			return logError(status.Errorf(codes.OutOfRange, "chunk ends after the image size: [%d > %d]", end, upload.Size))
+		}
+
+		upload, err = server.uploadStore.Append(upload.ID, int64(req.GetOffset()), chunkData)
+		if err != nil {
+			return logError(status.Errorf(storeErrorCode(err), "cannot write chunk: %v", err))
+		}
+	}
+
+	if upload == nil {
+		return logError(status.Error(codes.InvalidArgument, "no chunk received"))
+	}
+
+	res := &pb.UploadChunksResponse{
+		UploadId:        upload.ID,
+		CommittedOffset: uint64(upload.Offset),
+	}
+	err := stream.SendAndClose(res)
 	if err != nil {
 		return logError(status.Errorf(codes.Unknown, "cannot send response: %v", err))
 	}
+	return nil
+}
 
-	log.Printf("save image with laptop imageID: %s , size: %d", imageID, imageSize)
+// QueryUpload returns the committed offset of a resumable upload
+func (server *LaptopServer) QueryUpload(ctx context.Context, req *pb.QueryUploadRequest) (*pb.QueryUploadResponse, error) {
+	log.Printf("receive a query-upload request with id: %s", req.GetUploadId())
+
+	if server.uploadStore == nil {
+		return nil, logError(status.Error(codes.Unimplemented, "resumable uploads are not enabled"))
+	}
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	upload, err := server.findUpload(req.GetUploadId())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.QueryUploadResponse{
+		UploadId: upload.ID,
+		Info: &pb.ImageInfo{
+			LaptopId:  upload.LaptopID,
+			ImageType: upload.ImageType,
+		},
+		Size:            uint64(upload.Size),
+		CommittedOffset: uint64(upload.Offset),
+	}
+	return res, nil
+}
+
+// CommitUpload stores the image of a complete resumable upload if its checksum is the expected one
+func (server *LaptopServer) CommitUpload(ctx context.Context, req *pb.CommitUploadRequest) (*pb.UploadImageResponse, error) {
+	log.Printf("receive a commit-upload request with id: %s", req.GetUploadId())
+
+	if server.uploadStore == nil {
+		return nil, logError(status.Error(codes.Unimplemented, "resumable uploads are not enabled"))
+	}
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	checksum, err := hex.DecodeString(req.GetSha256())
+	if err != nil || len(checksum) != sha256.Size {
+		return nil, logError(status.Errorf(codes.InvalidArgument, "sha256 is not a hex encoded SHA-256: %q", req.GetSha256()))
+	}
+
+	upload, err := server.findUpload(req.GetUploadId())
+	if err != nil {
+		return nil, err
+	}
+	if upload.Size > 0 && upload.Offset != upload.Size {
+		return nil, logError(status.Errorf(codes.FailedPrecondition, "upload is incomplete: [%d < %d]", upload.Offset, upload.Size))
+	}
+	if !bytes.Equal(checksum, upload.SHA256) {
+		// the received bytes are corrupted, the upload must start again
+		server.uploadStore.Delete(upload.ID)
+		return nil, logError(status.Errorf(codes.DataLoss, "checksum mismatch: received %x", upload.SHA256))
+	}
+
+	reader, err := server.uploadStore.Open(upload.ID)
+	if err != nil {
+		return nil, logError(status.Errorf(storeErrorCode(err), "cannot open upload: %v", err))
+	}
+	imageID, deduplicated, err := server.imageStore.Save(upload.LaptopID, upload.ImageType, reader)
+	reader.Close()
+	if err != nil {
+		return nil, logError(status.Errorf(storeErrorCode(err), "cannot save image to the store: %v", err))
+	}
+
+	err = server.uploadStore.Delete(upload.ID)
+	if err != nil {
+		log.Printf("cannot delete committed upload: %v", err)
+	}
+	log.Printf("committed upload %s as image with id: %s, size: %d", upload.ID, imageID, upload.Offset)
+
+	res := &pb.UploadImageResponse{
+		Id:           imageID,
+		Size:         uint32(upload.Offset),
+		Deduplicated: deduplicated,
+	}
+	return res, nil
+}
+
+// findUpload returns the upload with an ID sent by a client, the upload IDs are part of the blob keys
+func (server *LaptopServer) findUpload(uploadID string) (*UploadInfo, error) {
+	_, err := uuid.Parse(uploadID)
+	if err != nil {
+		return nil, logError(status.Errorf(codes.InvalidArgument, "upload ID is not a valid UUID: %v", err))
+	}
+
+	upload, err := server.uploadStore.Find(uploadID)
+	if err != nil {
+		return nil, logError(status.Errorf(codes.Internal, "cannot find upload: %v", err))
+	}
+	if upload == nil {
+		return nil, logError(status.Errorf(codes.NotFound, "upload[%s] doesn't exists", uploadID))
+	}
+	return upload, nil
+}
+
+// ListImages returns the images of a laptop, the oldest first
+func (server *LaptopServer) ListImages(ctx context.Context, req *pb.ListImagesRequest) (*pb.ListImagesResponse, error) {
+	laptopID := req.GetLaptopId()
+	log.Printf("receive a list-images request for laptop[%s]", laptopID)
+
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	laptop, err := server.laptopStore.Find(laptopID)
+	if err != nil {
+		return nil, logError(status.Errorf(codes.Internal, "cannot find laptop: %v", err))
+	}
+	if laptop == nil {
+		return nil, logError(status.Errorf(codes.NotFound, "laptop[%s] doesn't exists", laptopID))
+	}
+
+	images, err := server.imageStore.List(laptopID)
+	if err != nil {
+		return nil, logError(status.Errorf(codes.Internal, "cannot list images: %v", err))
+	}
+
+	res := &pb.ListImagesResponse{
+		Images: make([]*pb.Image, len(images)),
+	}
+	for i, info := range images {
+		res.Images[i] = imageMessage(info)
+	}
+	return res, nil
+}
+
+// DownloadImage is server stream RPC to send an image, its info first and then its data in chunks
+func (server *LaptopServer) DownloadImage(req *pb.DownloadImageRequest, stream pb.LaptopService_DownloadImageServer) error {
+	imageID := req.GetId()
+	log.Printf("receive a download-image request with id: %s, size: %s", imageID, req.GetSize())
+
+	info, file, err := server.openImage(imageID, req.GetSize())
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	res := &pb.DownloadImageResponse{
+		Data: &pb.DownloadImageResponse_Info{Info: imageMessage(info)},
+	}
+	err = stream.Send(res)
+	if err != nil {
+		return logError(status.Errorf(codes.Unknown, "cannot send image info: %v", err))
+	}
+
+	buffer := make([]byte, ImageChunkSize)
+	for {
+		if err := contextError(stream.Context()); err != nil {
+			return err
+		}
+
+		n, err := file.Read(buffer)
+		if n > 0 {
+			res := &pb.DownloadImageResponse{
+				Data: &pb.DownloadImageResponse_ChunkData{ChunkData: buffer[:n]},
+			}
+			sendErr := stream.Send(res)
+			if sendErr != nil {
+				return logError(status.Errorf(codes.Unknown, "cannot send chunk data: %v", sendErr))
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return logError(status.Errorf(codes.Internal, "cannot read image: %v", err))
+		}
+	}
+
+	log.Printf("sent image with id: %s, size: %d", imageID, info.Size)
+	return nil
+}
+
+// GetImageContent returns the data of an image at once, the REST gateway writes it as the response body
+func (server *LaptopServer) GetImageContent(ctx context.Context, req *pb.GetImageContentRequest) (*httpbody.HttpBody, error) {
+	imageID := req.GetId()
+	log.Printf("receive a get-image-content request with id: %s, size: %s", imageID, req.GetSize())
+
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	info, file, err := server.openImage(imageID, req.GetSize())
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, logError(status.Errorf(codes.Internal, "cannot read image: %v", err))
+	}
+
+	res := &httpbody.HttpBody{
+		ContentType: info.ContentType(),
+		Data:        data,
+	}
+	return res, nil
+}
+
+// DeleteImage removes an image and its data
+func (server *LaptopServer) DeleteImage(ctx context.Context, req *pb.DeleteImageRequest) (*pb.DeleteImageResponse, error) {
+	imageID := req.GetId()
+	log.Printf("receive a delete-image request with id: %s", imageID)
+
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if err := checkImageID(imageID); err != nil {
+		return nil, err
+	}
+
+	err := server.imageStore.Delete(imageID)
+	if err != nil {
+		return nil, logError(status.Errorf(storeErrorCode(err), "cannot delete image: %v", err))
+	}
+	log.Printf("deleted image with id: %s", imageID)
+
+	res := &pb.DeleteImageResponse{
+		Id: imageID,
+	}
+	return res, nil
+}
+
+// checkImageID checks that an image ID sent by a client is a UUID, the image IDs are part of the blob keys
+func checkImageID(imageID string) error {
+	_, err := uuid.Parse(imageID)
+	if err != nil {
+		return logError(status.Errorf(codes.InvalidArgument, "image ID is not a valid UUID: %v", err))
+	}
 	return nil
 }
 
+// thumbnailSizes are the sizes in pixels of the thumbnails of an image
+var thumbnailSizes = map[pb.ImageSize]int{
+	pb.ImageSize_SMALL:  128,
+	pb.ImageSize_MEDIUM: 512,
+	pb.ImageSize_LARGE:  1024,
+}
+
+// openImage returns the info and the data of a stored image, or of its thumbnail if size is not ORIGINAL
+func (server *LaptopServer) openImage(imageID string, size pb.ImageSize) (*ImageInfo, io.ReadCloser, error) {
+	if err := checkImageID(imageID); err != nil {
+		return nil, nil, err
+	}
+	pixels, ok := thumbnailSizes[size]
+	if size != pb.ImageSize_ORIGINAL && !ok {
+		return nil, nil, logError(status.Errorf(codes.InvalidArgument, "unknown image size: %v", size))
+	}
+
+	info, err := server.imageStore.Find(imageID)
+	if err != nil {
+		return nil, nil, logError(status.Errorf(codes.Internal, "cannot find image: %v", err))
+	}
+	if info == nil {
+		return nil, nil, logError(status.Errorf(codes.NotFound, "image[%s] doesn't exists", imageID))
+	}
+
+	var file io.ReadCloser
+	if size == pb.ImageSize_ORIGINAL {
+		file, err = server.imageStore.Open(imageID)
+	} else {
+		file, err = server.imageStore.OpenThumbnail(imageID, pixels)
+	}
+	if err != nil {
+		return nil, nil, logError(status.Errorf(storeErrorCode(err), "cannot open image: %v", err))
+	}
+	return info, file, nil
+}
+
+func imageMessage(info *ImageInfo) *pb.Image {
+	return &pb.Image{
+		Id:          info.ID,
+		LaptopId:    info.LaptopID,
+		ImageType:   info.Type,
+		ContentType: info.ContentType(),
+		Size:        uint64(info.Size),
+		Sha256:      info.SHA256,
+		Width:       uint32(info.Width),
+		Height:      uint32(info.Height),
+	}
+}
+
+// RateLaptop is bidirectional stream RPC to rate laptops, a user has one score per laptop
 func (server *LaptopServer) RateLaptop(stream pb.LaptopService_RateLaptopServer) error {
+	username, err := authenticatedUsername(stream.Context())
+	if err != nil {
+		return err
+	}
+
 	for {
 		// timeout or cancel handle
 		if err := contextError(stream.Context()); err != nil {
@@ -216,7 +1103,10 @@ func (server *LaptopServer) RateLaptop(stream pb.LaptopService_RateLaptopServer)
 		// Get data and log
 		laptopId := req.GetLaptopId()
 		laptopScore := req.GetScore()
-		log.Printf("receive a rat-laptop stream with laptopID: %s, Score: %.2f", laptopId, laptopScore)
+		log.Printf("receive a rat-laptop stream with laptopID: %s, Score: %.2f, user: %s", laptopId, laptopScore, username)
+		if !(laptopScore >= MinScore && laptopScore <= MaxScore) {
+			return logError(status.Errorf(codes.InvalidArgument, "score must be between %d and %d: %v", MinScore, MaxScore, laptopScore))
+		}
 
 		// search data from server
 		found, err := server.laptopStore.Find(laptopId)
@@ -227,7 +1117,7 @@ func (server *LaptopServer) RateLaptop(stream pb.LaptopService_RateLaptopServer)
 			return logError(status.Error(codes.NotFound, "laptopID is not find"))
 		}
 
-		rating, err := server.ratingStore.Add(laptopId, laptopScore)
+		rating, err := server.ratingStore.Rate(laptopId, username, laptopScore)
 		if err != nil {
 			return logError(status.Error(codes.Internal, "not save laptop rat"))
 		}
@@ -235,7 +1125,7 @@ func (server *LaptopServer) RateLaptop(stream pb.LaptopService_RateLaptopServer)
 		res := &pb.RateLaptopResponse{
 			LaptopId:    laptopId,
 			RateCount:   rating.Count,
-			AverageRate: rating.Sum / float64(rating.Count),
+			AverageRate: rating.Average(),
 		}
 
 		err = stream.Send(res)
@@ -246,6 +1136,139 @@ func (server *LaptopServer) RateLaptop(stream pb.LaptopService_RateLaptopServer)
 	return nil
 }
 
+// DeleteRating removes the score of the authenticated user for a laptop
+func (server *LaptopServer) DeleteRating(ctx context.Context, req *pb.DeleteRatingRequest) (*pb.DeleteRatingResponse, error) {
+	laptopID := req.GetLaptopId()
+	log.Printf("receive a delete-rating request with laptop id: %s", laptopID)
+
+	username, err := authenticatedUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	rating, err := server.ratingStore.Delete(laptopID, username)
+	if err != nil {
+		return nil, logError(status.Errorf(storeErrorCode(err), "cannot delete rating: %v", err))
+	}
+	log.Printf("deleted rating of laptop %s by %s", laptopID, username)
+
+	res := &pb.DeleteRatingResponse{
+		LaptopId:    laptopID,
+		RateCount:   rating.Count,
+		AverageRate: rating.Average(),
+	}
+	return res, nil
+}
+
+// GetRating returns the statistics of the scores of a laptop
+func (server *LaptopServer) GetRating(ctx context.Context, req *pb.GetRatingRequest) (*pb.GetRatingResponse, error) {
+	laptopID := req.GetLaptopId()
+	log.Printf("receive a get-rating request with laptop id: %s", laptopID)
+
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	found, err := server.laptopStore.Find(laptopID)
+	if err != nil {
+		return nil, logError(status.Errorf(codes.Internal, "cannot find laptop: %v", err))
+	}
+	if found == nil {
+		return nil, logError(status.Errorf(codes.NotFound, "laptop[%s] doesn't exists", laptopID))
+	}
+
+	stats, err := server.ratingStore.Stats(laptopID)
+	if err != nil {
+		return nil, logError(status.Errorf(storeErrorCode(err), "cannot get rating: %v", err))
+	}
+
+	res := &pb.GetRatingResponse{
+		Rating: ratingStatsToPB(laptopID, stats),
+	}
+	return res, nil
+}
+
+// BatchGetRatings returns the statistics of the scores of many laptops, the laptops
+// without scores, including the laptops that don't exist, have a zero count
+func (server *LaptopServer) BatchGetRatings(ctx context.Context, req *pb.BatchGetRatingsRequest) (*pb.BatchGetRatingsResponse, error) {
+	laptopIDs := req.GetLaptopIds()
+	log.Printf("receive a batch-get-ratings request with %d laptops", len(laptopIDs))
+
+	if len(laptopIDs) > MaxBatchRatings {
+		return nil, logError(status.Errorf(codes.InvalidArgument, "batch is larger than %d laptops", MaxBatchRatings))
+	}
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	stats, err := server.ratingStore.BatchStats(laptopIDs)
+	if err != nil {
+		return nil, logError(status.Errorf(storeErrorCode(err), "cannot get ratings: %v", err))
+	}
+
+	res := &pb.BatchGetRatingsResponse{
+		Ratings: make([]*pb.RatingStats, len(laptopIDs)),
+	}
+	for i, laptopID := range laptopIDs {
+		res.Ratings[i] = ratingStatsToPB(laptopID, stats[laptopID])
+	}
+	return res, nil
+}
+
+// ratingStatsToPB converts the statistics of a laptop, nil stats are the statistics of no score
+func ratingStatsToPB(laptopID string, stats *RatingStats) *pb.RatingStats {
+	if stats == nil {
+		stats = &RatingStats{}
+	}
+	return &pb.RatingStats{
+		LaptopId:    laptopID,
+		RateCount:   stats.Count,
+		AverageRate: stats.Average,
+		MinScore:    stats.Min,
+		MaxScore:    stats.Max,
+		StddevScore: stats.StdDev,
+		Histogram:   append([]uint32(nil), stats.Histogram[:]...),
+		// the legacy scores are reported apart, the other statistics are computed from the same scores
+		LegacyRateCount:   stats.LegacyCount,
+		LegacyAverageRate: stats.LegacyAverage,
+	}
+}
+
+// authenticatedUsername returns the name of the user authenticated by the AuthInterceptor
+func authenticatedUsername(ctx context.Context) (string, error) {
+	claims := UserClaimsFromContext(ctx)
+	if claims == nil || claims.Username == "" {
+		return "", logError(status.Error(codes.Unauthenticated, "rating requires an authenticated user"))
+	}
+	return claims.Username, nil
+}
+
+// storeErrorCode returns the status code of a laptop store error
+func storeErrorCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, ErrAlreadyExists):
+		return codes.AlreadyExists
+	case errors.Is(err, ErrRevisionMismatch):
+		return codes.Aborted
+	case errors.Is(err, ErrUploadOffset):
+		return codes.FailedPrecondition
+	case errors.Is(err, ErrInvalidFacet), errors.Is(err, ErrInvalidResumeToken), errors.Is(err, ErrInvalidImage),
+		errors.Is(err, ErrInvalidBlobKey):
+		return codes.InvalidArgument
+	case errors.Is(err, ErrResumeTokenExpired):
+		return codes.OutOfRange
+	case errors.Is(err, ErrWatcherTooSlow):
+		return codes.ResourceExhausted
+	default:
+		return codes.Internal
+	}
+}
+
 func logError(err error) error {
 	if err != nil {
 		log.Println(err)
@@ -7,33 +7,92 @@ import (
 	"github.com/jinzhu/copier"
 	"library/v1/pb"
 	"log"
+	"strings"
 	"sync"
 )
 
 // ErrAlreadyExists is returned when a record with the same ID already exists in the store
 var ErrAlreadyExists = errors.New("record already exists")
 
+// ErrNotFound is returned when a record with the given ID doesn't exist in the store
+var ErrNotFound = errors.New("record not found")
+
+// ErrRevisionMismatch is returned when the stored revision of a record isn't the expected one
+var ErrRevisionMismatch = errors.New("record revision mismatch")
+
+// SaveError is the error of SaveAll, it names the laptop that couldn't be saved
+type SaveError struct {
+	// Index is the position of the laptop in the batch
+	Index int
+	Err   error
+}
+
+func (e *SaveError) Error() string {
+	return fmt.Sprintf("cannot save laptop %d of the batch: %v", e.Index, e.Err)
+}
+
+func (e *SaveError) Unwrap() error {
+	return e.Err
+}
+
 // LaptopStore is an interface to store laptop
 type LaptopStore interface {
-	// Save method saves the laptop to the store
+	// Save method saves the laptop to the store and sets its revision to 1
 	Save(laptop *pb.Laptop) error
 
+	// SaveAll saves every laptop or none of them, it returns a *SaveError
+	// if a laptop already exists or has the ID of another laptop of the batch
+	SaveAll(laptops []*pb.Laptop) error
+
 	// Find laptop from store
 	Find(id string) (*pb.Laptop, error)
 
 	// Search laptop from store
 	Search(ctx context.Context, filter *pb.Filter, found func(laptop *pb.Laptop) error) error
+
+	// SearchText finds the laptops that match filter and every word of text,
+	// found receives the relevance score of each laptop
+	SearchText(ctx context.Context, filter *pb.Filter, text string, found func(laptop *pb.Laptop, score float64) error) error
+
+	// Update replaces the stored laptop that has the same ID and increases its revision,
+	// a non-zero expectedRevision must match the stored revision
+	Update(laptop *pb.Laptop, expectedRevision uint64) error
+
+	// Delete removes the laptop with the given ID from the store,
+	// a non-zero expectedRevision must match the stored revision
+	Delete(id string, expectedRevision uint64) error
+
+	// Watch returns a watcher of the changes after resumeToken,
+	// or of the next changes if resumeToken is empty
+	Watch(resumeToken string) (*LaptopWatcher, error)
+
+	// Aggregate counts the laptops that match filter in the buckets of facets,
+	// and returns the number of laptops that match filter
+	Aggregate(ctx context.Context, filter *pb.Filter, facets []*pb.Facet) (uint64, []*pb.FacetResult, error)
 }
 
+// InMemoryLaptopStore stores laptops in memory.
+// A stored laptop is never modified, a change stores a new copy,
+// so Search can read the stored laptops after releasing the lock.
 type InMemoryLaptopStore struct {
-	mutex sync.RWMutex          // concurrency security
-	data  map[string]*pb.Laptop // save the *laptop
+	mutex   sync.RWMutex          // concurrency security
+	data    map[string]*pb.Laptop // save the *laptop
+	indexes *laptopIndexes        // sorted secondary indexes of data
+	text    *textIndex            // inverted index of the text of data
+	feed    *ChangeFeed           // changes of data, nil if they are published by a wrapping store
 }
 
 // NewInMemoryLaptopStore create a InMemoryLaptopStore
 func NewInMemoryLaptopStore() *InMemoryLaptopStore {
+	return newInMemoryLaptopStore(NewChangeFeed(DefaultFeedHistory, DefaultWatcherBuffer))
+}
+
+func newInMemoryLaptopStore(feed *ChangeFeed) *InMemoryLaptopStore {
 	return &InMemoryLaptopStore{
-		data: make(map[string]*pb.Laptop),
+		data:    make(map[string]*pb.Laptop),
+		indexes: newLaptopIndexes(),
+		text:    newTextIndex(),
+		feed:    feed,
 	}
 }
 
@@ -49,6 +108,7 @@ func (store *InMemoryLaptopStore) Save(laptop *pb.Laptop) error {
 	}
 
 	// Save the laptop
+	laptop.Revision = 1
 	// Security!!!, deep copy
 	//other := &pb.Laptop{}
 	//err := copier.Copy(other, laptop)
@@ -56,10 +116,49 @@ func (store *InMemoryLaptopStore) Save(laptop *pb.Laptop) error {
 	if err != nil {
 		return fmt.Errorf("cannot copy laptop data:%w", err)
 	}
-	store.data[other.Id] = other
+	store.store(other)
+	store.publish(pb.WatchLaptopsResponse_CREATED, other)
 	return nil
 }
 
+// SaveAll implement the LaptopStore interface
+func (store *InMemoryLaptopStore) SaveAll(laptops []*pb.Laptop) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	others, err := store.checkBatch(laptops)
+	if err != nil {
+		return err
+	}
+
+	for i, other := range others {
+		laptops[i].Revision = 1
+		other.Revision = 1
+		store.store(other)
+		store.publish(pb.WatchLaptopsResponse_CREATED, other)
+	}
+	return nil
+}
+
+// checkBatch returns copies of a batch of new laptops, the caller must hold the lock
+func (store *InMemoryLaptopStore) checkBatch(laptops []*pb.Laptop) ([]*pb.Laptop, error) {
+	ids := make(map[string]bool, len(laptops))
+	others := make([]*pb.Laptop, len(laptops))
+	for i, laptop := range laptops {
+		if store.data[laptop.Id] != nil || ids[laptop.Id] {
+			return nil, &SaveError{Index: i, Err: ErrAlreadyExists}
+		}
+		ids[laptop.Id] = true
+
+		other, err := deepCopy(laptop)
+		if err != nil {
+			return nil, &SaveError{Index: i, Err: err}
+		}
+		others[i] = other
+	}
+	return others, nil
+}
+
 // Find according to id find laptop
 func (store *InMemoryLaptopStore) Find(id string) (*pb.Laptop, error) {
 	store.mutex.RLock()
@@ -81,53 +180,368 @@ func (store *InMemoryLaptopStore) Find(id string) (*pb.Laptop, error) {
 	return deepCopy(laptop)
 }
 
-// Search according to filter find laptop
-func (store *InMemoryLaptopStore) Search(ctx context.Context, filter *pb.Filter, found func(laptop *pb.Laptop) error) error {
+// Update implement the LaptopStore interface
+func (store *InMemoryLaptopStore) Update(laptop *pb.Laptop, expectedRevision uint64) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	stored := store.data[laptop.Id]
+	if stored == nil {
+		return ErrNotFound
+	}
+	if expectedRevision != 0 && stored.Revision != expectedRevision {
+		return ErrRevisionMismatch
+	}
+
+	laptop.Revision = stored.Revision + 1
+	other, err := deepCopy(laptop)
+	if err != nil {
+		return err
+	}
+	store.store(other)
+	store.publish(pb.WatchLaptopsResponse_UPDATED, other)
+	return nil
+}
+
+// Delete implement the LaptopStore interface
+func (store *InMemoryLaptopStore) Delete(id string, expectedRevision uint64) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	stored := store.data[id]
+	if stored == nil {
+		return ErrNotFound
+	}
+	if expectedRevision != 0 && stored.Revision != expectedRevision {
+		return ErrRevisionMismatch
+	}
+
+	store.unstore(id)
+	store.publish(pb.WatchLaptopsResponse_DELETED, stored)
+	return nil
+}
+
+// Watch implement the LaptopStore interface
+func (store *InMemoryLaptopStore) Watch(resumeToken string) (*LaptopWatcher, error) {
+	return store.feed.Watch(resumeToken)
+}
+
+// publish sends a change to the watchers, the caller must hold the write lock
+// so the changes are published in order
+func (store *InMemoryLaptopStore) publish(eventType pb.WatchLaptopsResponse_Type, laptop *pb.Laptop) {
+	if store.feed != nil {
+		store.feed.publish(eventType, laptop)
+	}
+}
+
+// put stores a copy of the laptop without any check
+func (store *InMemoryLaptopStore) put(laptop *pb.Laptop) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	other, err := deepCopy(laptop)
+	if err != nil {
+		return err
+	}
+	store.store(other)
+	return nil
+}
+
+// remove deletes the laptop without any check
+func (store *InMemoryLaptopStore) remove(id string) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.unstore(id)
+}
+
+// store replaces the stored laptop with the same ID and updates the indexes,
+// the caller must hold the write lock
+func (store *InMemoryLaptopStore) store(laptop *pb.Laptop) {
+	store.unstore(laptop.Id)
+	store.data[laptop.Id] = laptop
+	store.indexes.insert(laptop)
+	store.text.insert(laptop)
+}
+
+// unstore deletes the laptop with the given ID and its index entries,
+// the caller must hold the write lock
+func (store *InMemoryLaptopStore) unstore(id string) {
+	stored := store.data[id]
+	if stored == nil {
+		return
+	}
+	store.indexes.remove(stored)
+	store.text.remove(stored)
+	delete(store.data, id)
+}
+
+// all returns a copy of every stored laptop
+func (store *InMemoryLaptopStore) all() ([]*pb.Laptop, error) {
 	store.mutex.RLock()
 	defer store.mutex.RUnlock()
+
+	laptops := make([]*pb.Laptop, 0, len(store.data))
 	for _, laptop := range store.data {
-		//time.Sleep(time.Second)
-		//log.Println("checking laptop id: ", laptop.GetId())
+		other, err := deepCopy(laptop)
+		if err != nil {
+			return nil, err
+		}
+		laptops = append(laptops, other)
+	}
+	return laptops, nil
+}
 
+// Search according to filter find laptop,
+// found is called after the lock is released so a slow caller doesn't block the writers
+func (store *InMemoryLaptopStore) Search(ctx context.Context, filter *pb.Filter, found func(laptop *pb.Laptop) error) error {
+	laptops := store.match(filter)
+
+	for _, laptop := range laptops {
 		// Deadline exceeded control
 		if ctx.Err() == context.DeadlineExceeded || ctx.Err() == context.Canceled {
 			log.Println("context is cancel")
 			return errors.New("context is cancel")
 		}
 
-		// Filter laptop
-		if isQualified(filter, laptop) {
-			other, err := deepCopy(laptop)
-			if err != nil {
-				return err
-			}
-			err = found(other)
-			if err != nil {
-				return err
-			}
+		// Security deep copy
+		other, err := deepCopy(laptop)
+		if err != nil {
+			return err
+		}
+		err = found(other)
+		if err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// SearchText implement the LaptopStore interface,
+// found is called after the lock is released like in Search
+func (store *InMemoryLaptopStore) SearchText(
+	ctx context.Context,
+	filter *pb.Filter,
+	text string,
+	found func(laptop *pb.Laptop, score float64) error,
+) error {
+	laptops, scores := store.matchText(filter, text)
+
+	for i, laptop := range laptops {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		other, err := deepCopy(laptop)
+		if err != nil {
+			return err
+		}
+		err = found(other, scores[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchText returns the stored laptops that match filter and text, and their scores
+func (store *InMemoryLaptopStore) matchText(filter *pb.Filter, text string) ([]*pb.Laptop, []float64) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	var laptops []*pb.Laptop
+	var scores []float64
+	for id, score := range store.text.search(text) {
+		laptop := store.data[id]
+		if isQualified(filter, laptop) {
+			laptops = append(laptops, laptop)
+			scores = append(scores, score)
+		}
+	}
+	return laptops, scores
+}
+
+// Aggregate implement the LaptopStore interface
+func (store *InMemoryLaptopStore) Aggregate(ctx context.Context, filter *pb.Filter, facets []*pb.Facet) (uint64, []*pb.FacetResult, error) {
+	counter, err := newFacetCounter(facets)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// the stored laptops are never modified, they are counted without a copy
+	for _, laptop := range store.match(filter) {
+		if ctx.Err() != nil {
+			return 0, nil, ctx.Err()
+		}
+		counter.add(laptop)
+	}
+	return counter.total, counter.results(), nil
+}
+
+// match returns the stored laptops that match filter,
+// the indexes narrow the candidates and isQualified checks the rest of the filter
+func (store *InMemoryLaptopStore) match(filter *pb.Filter) []*pb.Laptop {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	candidates, indexed := store.indexes.candidates(filter)
+	if !indexed {
+		return store.qualified(filter)
+	}
+
+	var laptops []*pb.Laptop
+	for _, entry := range candidates {
+		laptop := store.data[entry.id]
+		if isQualified(filter, laptop) {
+			laptops = append(laptops, laptop)
+		}
+	}
+	return laptops
+}
+
+// qualified returns the stored laptops that match filter, the caller must hold the lock
+func (store *InMemoryLaptopStore) qualified(filter *pb.Filter) []*pb.Laptop {
+	var laptops []*pb.Laptop
+	for _, laptop := range store.data {
+		if isQualified(filter, laptop) {
+			laptops = append(laptops, laptop)
+		}
+	}
+	return laptops
+}
+
 // Filter
 func isQualified(f *pb.Filter, l *pb.Laptop) bool {
-	if l.GetPriceUsd() > f.GetMaxPriceUsd() {
+	if f.GetMaxPriceUsd() > 0 && l.GetPriceUsd() > f.GetMaxPriceUsd() {
+		return false
+	}
+	if l.GetPriceUsd() < f.GetMinPriceUsd() {
+		return false
+	}
+	if l.GetCpu().GetNumCores() < f.GetMinCpuCores() {
+		return false
+	}
+	if l.GetCpu().GetMinGhz() < f.GetMinCpuGhz() {
+		return false
+	}
+	if toBit(l.GetRam()) < toBit(f.GetMinRam()) {
+		return false
+	}
+	if len(f.GetBrands()) > 0 && !containsFold(f.GetBrands(), l.GetBrand()) {
+		return false
+	}
+	if !strings.Contains(strings.ToLower(l.GetName()), strings.ToLower(f.GetName())) {
+		return false
+	}
+	if !hasQualifiedGPU(f, l.GetGpus()) {
+		return false
+	}
+	if !hasQualifiedStorage(f, l.GetStorages()) {
+		return false
+	}
+	if !isQualifiedScreen(f, l.GetScreen()) {
+		return false
+	}
+	if f.GetKeyboardLayout() != pb.Keyboard_UNKNOWN && l.GetKeyboard().GetLayout() != f.GetKeyboardLayout() {
 		return false
 	}
-	if l.GetCpu().GetNumCores() < f.MinCpuCores {
+	if f.GetKeyboardBacklit() != nil && l.GetKeyboard().GetBacklit() != f.GetKeyboardBacklit().GetValue() {
+		return false
+	}
+	if f.GetMaxWeightKg() > 0 && weightKg(l) > f.GetMaxWeightKg() {
+		return false
+	}
+	if l.GetReleaseYear() < f.GetMinReleaseYear() {
+		return false
+	}
+	if f.GetMaxReleaseYear() > 0 && l.GetReleaseYear() > f.GetMaxReleaseYear() {
+		return false
+	}
+	return true
+}
+
+// hasQualifiedGPU checks that one GPU matches both the GPU brand and memory of the filter
+func hasQualifiedGPU(f *pb.Filter, gpus []*pb.GPU) bool {
+	if len(f.GetGpuBrands()) == 0 && toBit(f.GetMinGpuMemory()) == 0 {
+		return true
+	}
+	for _, gpu := range gpus {
+		if len(f.GetGpuBrands()) > 0 && !containsFold(f.GetGpuBrands(), gpu.GetBrand()) {
+			continue
+		}
+		if toBit(gpu.GetMemory()) >= toBit(f.GetMinGpuMemory()) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasQualifiedStorage checks that one storage matches both the driver and capacity of the filter
+func hasQualifiedStorage(f *pb.Filter, storages []*pb.Storage) bool {
+	if f.GetStorageDriver() == pb.Storage_UNKNOWN && toBit(f.GetMinStorage()) == 0 {
+		return true
+	}
+	for _, storage := range storages {
+		if f.GetStorageDriver() != pb.Storage_UNKNOWN && storage.GetDriver() != f.GetStorageDriver() {
+			continue
+		}
+		if toBit(storage.GetMemory()) >= toBit(f.GetMinStorage()) {
+			return true
+		}
+	}
+	return false
+}
+
+func isQualifiedScreen(f *pb.Filter, screen *pb.Screen) bool {
+	if len(f.GetScreenPanels()) > 0 {
+		found := false
+		for _, panel := range f.GetScreenPanels() {
+			if screen.GetPanel() == panel {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if screen.GetSizeInch() < f.GetMinScreenSizeInch() {
 		return false
 	}
-	if l.GetCpu().GetMinGhz() < f.MinCpuGhz {
+	if screen.GetResolution().GetWidth() < f.GetMinScreenResolution().GetWidth() {
 		return false
 	}
-	if toBit(l.GetRam()) < toBit(f.MinRam) {
+	if screen.GetResolution().GetHeight() < f.GetMinScreenResolution().GetHeight() {
 		return false
 	}
 	return true
 }
 
+// kgPerLb is the number of kilograms in a pound
+const kgPerLb = 0.45359237
+
+// weightKg returns the laptop weight in kilograms whatever the unit it is saved in
+func weightKg(l *pb.Laptop) float64 {
+	switch weight := l.GetWeight().(type) {
+	case *pb.Laptop_WeightKg:
+		return weight.WeightKg
+	case *pb.Laptop_WeightLb:
+		return weight.WeightLb * kgPerLb
+	default:
+		return 0
+	}
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
 // To transfer bit unit,
 func toBit(m *pb.Memory) uint64 {
 	value := m.GetValue()
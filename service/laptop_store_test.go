@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"library/v1/pb"
+	"library/v1/sample"
+	"sort"
+	"testing"
+)
+
+// TestIsQualified test every filter criterion against a known laptop
+func TestIsQualified(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	laptop.Brand = "Apple"
+	laptop.Name = "MacBook PRO"
+	laptop.PriceUsd = 2500
+	laptop.ReleaseYear = 2021
+	laptop.Weight = &pb.Laptop_WeightLb{WeightLb: 3}
+	laptop.Gpus = []*pb.GPU{
+		{Brand: "NVIDIA", Memory: &pb.Memory{Value: 4, Unit: pb.Memory_GIGABYTE}},
+		{Brand: "AMD", Memory: &pb.Memory{Value: 8, Unit: pb.Memory_GIGABYTE}},
+	}
+	laptop.Storages = []*pb.Storage{
+		{Driver: pb.Storage_SSD, Memory: &pb.Memory{Value: 512, Unit: pb.Memory_GIGABYTE}},
+		{Driver: pb.Storage_HHD, Memory: &pb.Memory{Value: 2, Unit: pb.Memory_TERABYTE}},
+	}
+	laptop.Screen = &pb.Screen{
+		SizeInch:   15.6,
+		Resolution: &pb.Screen_Resolution{Width: 1920, Height: 1080},
+		Panel:      pb.Screen_OLED,
+	}
+	laptop.Keyboard = &pb.Keyboard{Layout: pb.Keyboard_QWERTY, Backlit: true}
+
+	gb := func(value uint64) *pb.Memory {
+		return &pb.Memory{Value: value, Unit: pb.Memory_GIGABYTE}
+	}
+
+	testCase := []struct {
+		name      string
+		filter    *pb.Filter
+		qualified bool
+	}{
+		{"empty_filter", &pb.Filter{}, true},
+		{"nil_filter", nil, true},
+		{"brand", &pb.Filter{Brands: []string{"dell", "apple"}}, true},
+		{"brand_mismatch", &pb.Filter{Brands: []string{"Dell"}}, false},
+		{"name", &pb.Filter{Name: "macbook"}, true},
+		{"name_mismatch", &pb.Filter{Name: "thinkpad"}, false},
+		{"price_range", &pb.Filter{MinPriceUsd: 2000, MaxPriceUsd: 3000}, true},
+		{"min_price", &pb.Filter{MinPriceUsd: 3000}, false},
+		{"max_price", &pb.Filter{MaxPriceUsd: 2000}, false},
+		{"gpu", &pb.Filter{GpuBrands: []string{"AMD"}, MinGpuMemory: gb(8)}, true},
+		{"gpu_same_card", &pb.Filter{GpuBrands: []string{"NVIDIA"}, MinGpuMemory: gb(8)}, false},
+		{"gpu_memory", &pb.Filter{MinGpuMemory: &pb.Memory{Value: 8192, Unit: pb.Memory_MEGABYTE}}, true},
+		{"storage", &pb.Filter{StorageDriver: pb.Storage_SSD, MinStorage: gb(512)}, true},
+		{"storage_same_driver", &pb.Filter{StorageDriver: pb.Storage_SSD, MinStorage: gb(1024)}, false},
+		{"storage_capacity", &pb.Filter{MinStorage: &pb.Memory{Value: 2, Unit: pb.Memory_TERABYTE}}, true},
+		{"screen", &pb.Filter{
+			ScreenPanels:        []pb.Screen_Panel{pb.Screen_OLED},
+			MinScreenSizeInch:   15,
+			MinScreenResolution: &pb.Screen_Resolution{Width: 1920, Height: 1080},
+		}, true},
+		{"screen_panel", &pb.Filter{ScreenPanels: []pb.Screen_Panel{pb.Screen_IPS}}, false},
+		{"screen_size", &pb.Filter{MinScreenSizeInch: 16}, false},
+		{"screen_resolution", &pb.Filter{MinScreenResolution: &pb.Screen_Resolution{Width: 2560}}, false},
+		{"keyboard", &pb.Filter{KeyboardLayout: pb.Keyboard_QWERTY, KeyboardBacklit: wrapperspb.Bool(true)}, true},
+		{"keyboard_layout", &pb.Filter{KeyboardLayout: pb.Keyboard_AZERTY}, false},
+		{"keyboard_backlit", &pb.Filter{KeyboardBacklit: wrapperspb.Bool(false)}, false},
+		{"weight", &pb.Filter{MaxWeightKg: 1.4}, true},
+		{"weight_lb_converted", &pb.Filter{MaxWeightKg: 1.3}, false},
+		{"release_year", &pb.Filter{MinReleaseYear: 2020, MaxReleaseYear: 2021}, true},
+		{"min_release_year", &pb.Filter{MinReleaseYear: 2022}, false},
+		{"max_release_year", &pb.Filter{MaxReleaseYear: 2020}, false},
+	}
+
+	for i := range testCase {
+		tc := testCase[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.qualified, isQualified(tc.filter, laptop))
+		})
+	}
+}
+
+// scan returns the stored laptops that match filter without using the indexes
+func (store *InMemoryLaptopStore) scan(filter *pb.Filter) []*pb.Laptop {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	return store.qualified(filter)
+}
+
+// TestInMemoryLaptopStoreIndexedSearch checks that the indexes find the same laptops as a scan
+func TestInMemoryLaptopStoreIndexedSearch(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	laptops := make([]*pb.Laptop, 200)
+	for i := range laptops {
+		laptops[i] = sample.NewLaptop()
+		require.NoError(t, store.Save(laptops[i]))
+	}
+	for i := 0; i < 50; i++ {
+		laptop := laptops[i]
+		laptop.PriceUsd = 1000 + float64(i)
+		laptop.Brand = "Dell"
+		require.NoError(t, store.Update(laptop, 0))
+	}
+	for i := 50; i < 70; i++ {
+		require.NoError(t, store.Delete(laptops[i].Id, 0))
+	}
+
+	filters := []*pb.Filter{
+		nil,
+		{MaxPriceUsd: 1025},
+		{MinPriceUsd: 1010, MaxPriceUsd: 3000},
+		{MinCpuCores: 6},
+		{MinCpuGhz: 4.2},
+		{MinRam: &pb.Memory{Value: 32, Unit: pb.Memory_GIGABYTE}},
+		{Brands: []string{"dell", "DELL", "Apple"}},
+		{Brands: []string{"Acer"}},
+		{MaxPriceUsd: 4000, MinCpuCores: 4, MinCpuGhz: 3, Brands: []string{"Lenovo"}},
+	}
+
+	ids := func(laptops []*pb.Laptop) []string {
+		result := make([]string, len(laptops))
+		for i, laptop := range laptops {
+			result[i] = laptop.Id
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	for _, filter := range filters {
+		require.Equal(t, ids(store.scan(filter)), ids(store.match(filter)), "filter: %v", filter)
+	}
+}
+
+// TestInMemoryLaptopStoreSearchUnlocked checks that found can write to the store
+func TestInMemoryLaptopStoreSearchUnlocked(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	require.NoError(t, store.Save(sample.NewLaptop()))
+
+	err := store.Search(context.Background(), nil, func(laptop *pb.Laptop) error {
+		return store.Save(sample.NewLaptop())
+	})
+	require.NoError(t, err)
+}
+
+func newBenchmarkStore(b *testing.B, n int) *InMemoryLaptopStore {
+	store := NewInMemoryLaptopStore()
+	for i := 0; i < n; i++ {
+		err := store.Save(sample.NewLaptop())
+		require.NoError(b, err)
+	}
+	return store
+}
+
+var benchmarkFilter = &pb.Filter{
+	MaxPriceUsd: 2100,
+	MinCpuCores: 4,
+	MinCpuGhz:   2.5,
+	MinRam:      &pb.Memory{Value: 4, Unit: pb.Memory_GIGABYTE},
+}
+
+func BenchmarkLaptopStoreScan(b *testing.B) {
+	store := newBenchmarkStore(b, 50000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		store.scan(benchmarkFilter)
+	}
+}
+
+func BenchmarkLaptopStoreIndexed(b *testing.B) {
+	store := newBenchmarkStore(b, 50000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		store.match(benchmarkFilter)
+	}
+}
+
+// BenchmarkLaptopStoreSave measures a save in a large store, the cost of the indexes grows as log n
+func BenchmarkLaptopStoreSave(b *testing.B) {
+	store := newBenchmarkStore(b, 50000)
+	laptops := make([]*pb.Laptop, b.N)
+	for i := range laptops {
+		laptops[i] = sample.NewLaptop()
+	}
+	b.ResetTimer()
+
+	for _, laptop := range laptops {
+		err := store.Save(laptop)
+		require.NoError(b, err)
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"library/v1/pb"
+	"math"
+	"strings"
+	"time"
+)
+
+// ValidationError lists every invalid field of a laptop
+type ValidationError struct {
+	// Violations name the fields by their path in the laptop, such as cpu.num_cores or gpus[0].memory.unit
+	Violations []*errdetails.BadRequest_FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		fields[i] = violation.GetField() + ": " + violation.GetDescription()
+	}
+	return "invalid laptop: " + strings.Join(fields, ", ")
+}
+
+// validator collects the field violations of a laptop
+type validator struct {
+	violations []*errdetails.BadRequest_FieldViolation
+}
+
+func (v *validator) add(field string, format string, args ...interface{}) {
+	v.violations = append(v.violations, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+// ValidateLaptop returns a *ValidationError if a field of laptop has a value the service cannot store.
+// The ID may be empty, the server generates it.
+func ValidateLaptop(laptop *pb.Laptop) error {
+	v := &validator{}
+	if laptop.GetId() != "" {
+		_, err := uuid.Parse(laptop.GetId())
+		if err != nil {
+			v.add("id", "must be a valid UUID")
+		}
+	}
+	if strings.TrimSpace(laptop.GetBrand()) == "" {
+		v.add("brand", "is required")
+	}
+	if strings.TrimSpace(laptop.GetName()) == "" {
+		v.add("name", "is required")
+	}
+
+	if laptop.GetCpu() == nil {
+		v.add("cpu", "is required")
+	} else {
+		v.validateCPU("cpu", laptop.GetCpu())
+	}
+	if laptop.GetRam() == nil {
+		v.add("ram", "is required")
+	} else {
+		v.validateMemory("ram", laptop.GetRam())
+	}
+	for i, gpu := range laptop.GetGpus() {
+		v.validateGPU(fmt.Sprintf("gpus[%d]", i), gpu)
+	}
+	for i, storage := range laptop.GetStorages() {
+		v.validateStorage(fmt.Sprintf("storages[%d]", i), storage)
+	}
+	if laptop.GetScreen() != nil {
+		v.validateScreen("screen", laptop.GetScreen())
+	}
+
+	switch laptop.GetWeight().(type) {
+	case *pb.Laptop_WeightKg:
+		v.positive("weight_kg", laptop.GetWeightKg())
+	case *pb.Laptop_WeightLb:
+		v.positive("weight_lb", laptop.GetWeightLb())
+	}
+
+	price := laptop.GetPriceUsd()
+	if price < 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+		v.add("price_usd", "must not be negative")
+	}
+	if year := laptop.GetReleaseYear(); year > uint32(time.Now().Year()) {
+		v.add("release_year", "must not be in the future")
+	}
+
+	if len(v.violations) > 0 {
+		return &ValidationError{Violations: v.violations}
+	}
+	return nil
+}
+
+func (v *validator) validateCPU(field string, cpu *pb.CPU) {
+	if cpu.GetNumCores() == 0 {
+		v.add(field+".num_cores", "must be positive")
+	}
+	if cpu.GetNumThreads() < cpu.GetNumCores() {
+		v.add(field+".num_threads", "must not be less than num_cores")
+	}
+	v.frequencies(field, cpu.GetMinGhz(), cpu.GetMaxGhz())
+}
+
+func (v *validator) validateGPU(field string, gpu *pb.GPU) {
+	v.frequencies(field, gpu.GetMinGhz(), gpu.GetMaxGhz())
+	if gpu.GetMemory() != nil {
+		v.validateMemory(field+".memory", gpu.GetMemory())
+	}
+}
+
+func (v *validator) validateStorage(field string, storage *pb.Storage) {
+	if storage.GetDriver() == pb.Storage_UNKNOWN {
+		v.add(field+".driver", "must be known")
+	}
+	if storage.GetMemory() == nil {
+		v.add(field+".memory", "is required")
+	} else {
+		v.validateMemory(field+".memory", storage.GetMemory())
+	}
+}
+
+func (v *validator) validateScreen(field string, screen *pb.Screen) {
+	v.positive(field+".size_inch", float64(screen.GetSizeInch()))
+	if resolution := screen.GetResolution(); resolution != nil {
+		if resolution.GetWidth() == 0 {
+			v.add(field+".resolution.width", "must be positive")
+		}
+		if resolution.GetHeight() == 0 {
+			v.add(field+".resolution.height", "must be positive")
+		}
+	}
+}
+
+func (v *validator) validateMemory(field string, memory *pb.Memory) {
+	if memory.GetValue() == 0 {
+		v.add(field+".value", "must be positive")
+	}
+	if memory.GetUnit() == pb.Memory_UNKNOWN {
+		v.add(field+".unit", "must be known")
+	}
+}
+
+// frequencies checks the min_ghz and max_ghz fields of a processor
+func (v *validator) frequencies(field string, minGhz float64, maxGhz float64) {
+	v.positive(field+".min_ghz", minGhz)
+	if maxGhz < minGhz {
+		v.add(field+".max_ghz", "must not be less than min_ghz")
+	}
+}
+
+func (v *validator) positive(field string, value float64) {
+	// NaN is not positive either
+	if !(value > 0) || math.IsInf(value, 0) {
+		v.add(field, "must be positive")
+	}
+}
+
+// invalidLaptopError returns an InvalidArgument status with the violations of err as a BadRequest detail,
+// their fields are prefixed by the request field of the laptop
+func invalidLaptopError(err error, prefix string) error {
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "invalid laptop: %v", err)
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range validationErr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       prefix + "." + violation.GetField(),
+			Description: violation.GetDescription(),
+		})
+	}
+
+	st := status.Newf(codes.InvalidArgument, "invalid laptop: %d invalid fields", len(badRequest.FieldViolations))
+	detailed, detailErr := st.WithDetails(badRequest)
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"library/v1/pb"
+	"library/v1/sample"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestValidateLaptop(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		change func(laptop *pb.Laptop)
+		fields []string
+	}{
+		{
+			name:   "valid",
+			change: func(laptop *pb.Laptop) {},
+		},
+		{
+			name:   "valid_no_id",
+			change: func(laptop *pb.Laptop) { laptop.Id = "" },
+		},
+		{
+			name:   "negative_price",
+			change: func(laptop *pb.Laptop) { laptop.PriceUsd = -1 },
+			fields: []string{"price_usd"},
+		},
+		{
+			name:   "nan_price",
+			change: func(laptop *pb.Laptop) { laptop.PriceUsd = math.NaN() },
+			fields: []string{"price_usd"},
+		},
+		{
+			name: "zero_cores",
+			change: func(laptop *pb.Laptop) {
+				laptop.Cpu.NumCores = 0
+			},
+			fields: []string{"cpu.num_cores"},
+		},
+		{
+			name: "cpu_min_above_max",
+			change: func(laptop *pb.Laptop) {
+				laptop.Cpu.MinGhz = 4.5
+				laptop.Cpu.MaxGhz = 3.5
+			},
+			fields: []string{"cpu.max_ghz"},
+		},
+		{
+			name: "cores_above_threads",
+			change: func(laptop *pb.Laptop) {
+				laptop.Cpu.NumCores = 8
+				laptop.Cpu.NumThreads = 4
+			},
+			fields: []string{"cpu.num_threads"},
+		},
+		{
+			name: "unknown_memory_unit",
+			change: func(laptop *pb.Laptop) {
+				laptop.Ram.Unit = pb.Memory_UNKNOWN
+				laptop.Storages[1].Memory.Unit = pb.Memory_UNKNOWN
+			},
+			fields: []string{"ram.unit", "storages[1].memory.unit"},
+		},
+		{
+			name: "future_release_year",
+			change: func(laptop *pb.Laptop) {
+				laptop.ReleaseYear = uint32(time.Now().Year() + 1)
+			},
+			fields: []string{"release_year"},
+		},
+		{
+			name: "every_bad_field",
+			change: func(laptop *pb.Laptop) {
+				laptop.Id = "invalid-id"
+				laptop.Brand = " "
+				laptop.Cpu.NumCores = 0
+				laptop.Ram = nil
+				laptop.Gpus[0].MinGhz = 0
+				laptop.Storages[0].Driver = pb.Storage_UNKNOWN
+				laptop.Screen.SizeInch = 0
+				laptop.Weight = &pb.Laptop_WeightLb{WeightLb: -2}
+				laptop.PriceUsd = -1
+			},
+			fields: []string{
+				"id",
+				"brand",
+				"cpu.num_cores",
+				"ram",
+				"gpus[0].min_ghz",
+				"storages[0].driver",
+				"screen.size_inch",
+				"weight_lb",
+				"price_usd",
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			laptop := sample.NewLaptop()
+			tc.change(laptop)
+			err := ValidateLaptop(laptop)
+			if len(tc.fields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			validationErr, ok := err.(*ValidationError)
+			require.True(t, ok)
+			fields := make([]string, len(validationErr.Violations))
+			for i, violation := range validationErr.Violations {
+				fields[i] = violation.GetField()
+				require.NotEmpty(t, violation.GetDescription())
+			}
+			require.Equal(t, tc.fields, fields)
+		})
+	}
+}
+
+func TestLaptopServerFieldViolations(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	server := NewLaptopServer(store, nil, nil)
+
+	laptop := sample.NewLaptop()
+	laptop.PriceUsd = -1
+	laptop.Cpu.NumCores = 0
+	_, err := server.CreateLaptop(context.Background(), &pb.CreateLaptopRequest{Laptop: laptop})
+	requireFieldViolations(t, err, "laptop.cpu.num_cores", "laptop.price_usd")
+	found, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	laptop = sample.NewLaptop()
+	err = store.Save(laptop)
+	require.NoError(t, err)
+
+	change := &pb.Laptop{Id: laptop.Id, ReleaseYear: uint32(time.Now().Year() + 1)}
+	req := &pb.UpdateLaptopRequest{Laptop: change, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"release_year"}}}
+	_, err = server.UpdateLaptop(context.Background(), req)
+	requireFieldViolations(t, err, "laptop.release_year")
+
+	found, err = store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Equal(t, laptop.ReleaseYear, found.ReleaseYear)
+}
+
+// requireFieldViolations checks that err is an InvalidArgument status with a BadRequest detail listing fields
+func requireFieldViolations(t *testing.T, err error, fields ...string) {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	var found []string
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, violation := range badRequest.GetFieldViolations() {
+			found = append(found, violation.GetField())
+		}
+	}
+	require.Equal(t, fields, found)
+}
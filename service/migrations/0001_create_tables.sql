@@ -0,0 +1,26 @@
+CREATE TABLE laptops (
+    id            VARCHAR(36) PRIMARY KEY,
+    revision      BIGINT      NOT NULL,
+    price_usd     DOUBLE      NOT NULL,
+    cpu_num_cores INTEGER     NOT NULL,
+    cpu_min_ghz   DOUBLE      NOT NULL,
+    ram_bits      BIGINT      NOT NULL,
+    data          BLOB        NOT NULL
+);
+
+CREATE INDEX laptops_price_usd ON laptops (price_usd);
+CREATE INDEX laptops_cpu_num_cores ON laptops (cpu_num_cores);
+CREATE INDEX laptops_cpu_min_ghz ON laptops (cpu_min_ghz);
+CREATE INDEX laptops_ram_bits ON laptops (ram_bits);
+
+CREATE TABLE ratings (
+    laptop_id VARCHAR(36) PRIMARY KEY,
+    count     INTEGER     NOT NULL,
+    sum       DOUBLE      NOT NULL
+);
+
+CREATE TABLE users (
+    username      VARCHAR(255) PRIMARY KEY,
+    hash_password VARCHAR(255) NOT NULL,
+    role          VARCHAR(64)  NOT NULL
+);
@@ -0,0 +1,8 @@
+-- the ratings keep the count and the sum of the scores, the scores rated before
+-- this migration have no user
+CREATE TABLE rating_scores (
+    laptop_id VARCHAR(36)  NOT NULL,
+    username  VARCHAR(255) NOT NULL,
+    score     DOUBLE       NOT NULL,
+    PRIMARY KEY (laptop_id, username)
+);
@@ -3,18 +3,33 @@ package service
 import (
 	"bufio"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"image/png"
 	"io"
+	"io/ioutil"
+	"library/v1/client"
 	"library/v1/pb"
 	"library/v1/sample"
 	"library/v1/serializer"
 	"log"
+	"math"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // TestClientCreateLaptop test create laptop service
@@ -38,6 +53,7 @@ func TestClientCreateLaptop(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	require.Equal(t, expectedID, res.Id)
+	require.EqualValues(t, 1, res.Revision)
 
 	// Check that the laptop is saved to the store
 	other, err := laptopStore.Find(res.Id)
@@ -45,6 +61,7 @@ func TestClientCreateLaptop(t *testing.T) {
 	require.NotNil(t, other)
 
 	// Check that the saved laptop is the same as the one we send
+	laptop.Revision = res.Revision
 	requireSameLaptop(t, laptop, other)
 
 }
@@ -230,11 +247,378 @@ func TestUploadImageClient(t *testing.T) {
 	require.NotZero(t, res.GetId())
 	require.EqualValues(t, res.GetSize(), size)
 
-	saveImagePath := fmt.Sprintf("%s/%s%s", testImageFolder, res.GetId(), filepath.Ext(filePath))
-	require.FileExists(t, saveImagePath)
+	info, err := imageStore.Find(res.GetId())
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(testImageFolder, "blobs", filepath.FromSlash(info.BlobKey)))
+}
+
+// TestImageClient test list, download and delete image
+func TestImageClient(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := NewInMemoryLaptopStore()
+	imageStore := NewDiskImageStore(t.TempDir())
+	laptop := sample.NewLaptop()
+	err := laptopStore.Save(laptop)
+	require.NoError(t, err)
+
+	laptopServer := NewLaptopServer(laptopStore, imageStore, nil)
+	serverAddress := startTestLaptopServe(t, laptopStore, imageStore, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	// larger than a chunk to be downloaded in several messages
+	data := newTestImage(t, "png", 256, 192)
+	require.Greater(t, len(data), 2*ImageChunkSize)
+	jpegData := newTestImage(t, "jpeg", 16, 16)
+	first := uploadTestImage(t, laptopClient, laptop.GetId(), ".png", data).GetId()
+	second := uploadTestImage(t, laptopClient, laptop.GetId(), ".jpg", jpegData).GetId()
+
+	list, err := laptopClient.ListImages(context.Background(), &pb.ListImagesRequest{LaptopId: laptop.GetId()})
+	require.NoError(t, err)
+	require.Len(t, list.GetImages(), 2)
+	require.Equal(t, first, list.GetImages()[0].GetId())
+	require.Equal(t, "image/png", list.GetImages()[0].GetContentType())
+	require.EqualValues(t, len(data), list.GetImages()[0].GetSize())
+	require.EqualValues(t, 256, list.GetImages()[0].GetWidth())
+	require.EqualValues(t, 192, list.GetImages()[0].GetHeight())
+	require.Equal(t, second, list.GetImages()[1].GetId())
+
+	_, err = laptopClient.ListImages(context.Background(), &pb.ListImagesRequest{LaptopId: "unknown"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	stream, err := laptopClient.DownloadImage(context.Background(), &pb.DownloadImageRequest{Id: first})
+	require.NoError(t, err)
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, laptop.GetId(), res.GetInfo().GetLaptopId())
+	downloaded := []byte{}
+	chunks := 0
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		chunks++
+		downloaded = append(downloaded, res.GetChunkData()...)
+	}
+	require.Equal(t, (len(data)+ImageChunkSize-1)/ImageChunkSize, chunks)
+	require.Equal(t, data, downloaded)
+
+	// the image fits in a large thumbnail, it is sent as is
+	stream, err = laptopClient.DownloadImage(context.Background(), &pb.DownloadImageRequest{Id: first, Size: pb.ImageSize_LARGE})
+	require.NoError(t, err)
+	downloaded = []byte{}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		downloaded = append(downloaded, res.GetChunkData()...)
+	}
+	require.Equal(t, data, downloaded)
+
+	// the REST gateway writes the image as the response body
+	mux := runtime.NewServeMux()
+	err = pb.RegisterLaptopServiceHandlerServer(context.Background(), mux, laptopServer)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/image/"+second, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "image/jpeg", recorder.Header().Get("Content-Type"))
+	require.Equal(t, jpegData, recorder.Body.Bytes())
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/image/"+first+"?size=SMALL", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+	config, err := png.DecodeConfig(recorder.Body)
+	require.NoError(t, err)
+	require.Equal(t, 128, config.Width)
+	require.Equal(t, 96, config.Height)
+
+	_, err = laptopClient.DeleteImage(context.Background(), &pb.DeleteImageRequest{Id: first})
+	require.NoError(t, err)
+	_, err = laptopClient.DeleteImage(context.Background(), &pb.DeleteImageRequest{Id: first})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	stream, err = laptopClient.DownloadImage(context.Background(), &pb.DownloadImageRequest{Id: first})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// the image IDs are checked before they are part of a blob key
+	for _, imageID := range []string{"../../laptop", "../images/" + second} {
+		stream, err = laptopClient.DownloadImage(context.Background(), &pb.DownloadImageRequest{Id: imageID})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.InvalidArgument, status.Code(err), imageID)
+		_, err = laptopClient.DeleteImage(context.Background(), &pb.DeleteImageRequest{Id: imageID})
+		require.Equal(t, codes.InvalidArgument, status.Code(err), imageID)
+	}
+
+	list, err = laptopClient.ListImages(context.Background(), &pb.ListImagesRequest{LaptopId: laptop.GetId()})
+	require.NoError(t, err)
+	require.Len(t, list.GetImages(), 1)
+	require.Equal(t, second, list.GetImages()[0].GetId())
+
+	// the same data uploaded again shares the stored image
+	uploadRes := uploadTestImage(t, laptopClient, laptop.GetId(), ".jpg", jpegData)
+	require.True(t, uploadRes.GetDeduplicated())
+	require.NotEqual(t, second, uploadRes.GetId())
+}
+
+// TestUploadImageLimits test the rejected uploads leave no file
+func TestUploadImageLimits(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := NewInMemoryLaptopStore()
+	laptop := sample.NewLaptop()
+	err := laptopStore.Save(laptop)
+	require.NoError(t, err)
+
+	// the image is followed by zeros up to the size of the upload, an upload of size 0 sends zeros only
+	imageData := newTestImage(t, "png", 8, 8)
+
+	testCases := []struct {
+		name      string
+		imageType string
+		size      int
+		code      codes.Code
+	}{
+		{
+			name:      "success",
+			imageType: ".png",
+			size:      5000,
+			code:      codes.OK,
+		},
+		{
+			name:      "failure_too_large",
+			imageType: ".png",
+			size:      5001,
+			code:      codes.InvalidArgument,
+		},
+		{
+			name:      "failure_not_allowed",
+			imageType: ".pdf",
+			size:      10,
+			code:      codes.InvalidArgument,
+		},
+		{
+			name:      "failure_not_image",
+			imageType: ".png",
+			size:      0,
+			code:      codes.InvalidArgument,
+		},
+		{
+			name:      "failure_image_type",
+			imageType: "/../../laptop.png",
+			size:      10,
+			code:      codes.InvalidArgument,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			folder := t.TempDir()
+			imageStore := NewDiskImageStore(folder)
+			laptopServer := NewLaptopServer(laptopStore, imageStore, nil, WithMaxImageSize(5000))
+			grpcServer := grpc.NewServer()
+			pb.RegisterLaptopServiceServer(grpcServer, laptopServer)
+			listener, err := net.Listen("tcp", ":0")
+			require.NoError(t, err)
+			go grpcServer.Serve(listener)
+			defer grpcServer.Stop()
+			laptopClient := newTestLaptopClient(t, listener.Addr().String())
+
+			stream, err := laptopClient.UploadImage(context.Background())
+			require.NoError(t, err)
+			err = stream.Send(&pb.UploadImageRequest{
+				Data: &pb.UploadImageRequest_Info{
+					Info: &pb.ImageInfo{LaptopId: laptop.GetId(), ImageType: tc.imageType},
+				},
+			})
+			require.NoError(t, err)
+			data := make([]byte, 1000)
+			if tc.size > 0 {
+				data = make([]byte, tc.size)
+				copy(data, imageData)
+			}
+			// the server may have closed the stream already, CloseAndRecv reports why
+			for sent := 0; sent < len(data); sent += 1000 {
+				n := len(data) - sent
+				if n > 1000 {
+					n = 1000
+				}
+				err = stream.Send(&pb.UploadImageRequest{
+					Data: &pb.UploadImageRequest_ChunkData{ChunkData: data[sent : sent+n]},
+				})
+				if err != nil {
+					break
+				}
+			}
+			res, err := stream.CloseAndRecv()
+
+			files, readErr := ioutil.ReadDir(folder)
+			require.NoError(t, readErr)
+			if tc.code != codes.OK {
+				require.Equal(t, tc.code, status.Code(err))
+				require.Empty(t, files)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.size, res.GetSize())
+			require.Len(t, files, 1)
+		})
+	}
+}
+
+// TestResumableUploadClient test an upload resumed after a dropped stream
+func TestResumableUploadClient(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := NewInMemoryLaptopStore()
+	laptop := sample.NewLaptop()
+	err := laptopStore.Save(laptop)
+	require.NoError(t, err)
+
+	folder := t.TempDir()
+	imageStore := NewDiskImageStore(folder)
+	uploadStore := NewBlobUploadStore(NewLocalBlobStore(filepath.Join(folder, "uploads")))
+	laptopServer := NewLaptopServer(laptopStore, imageStore, nil, WithUploadStore(uploadStore))
+
+	// the first stream of chunks is dropped after two chunks
+	var streams int32
+	dropStream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if info.FullMethod != "/techschool.proto.LaptopService/UploadChunks" || atomic.AddInt32(&streams, 1) > 1 {
+			return handler(srv, ss)
+		}
+		handler(srv, &droppedStream{ServerStream: ss, remaining: 2})
+		return status.Error(codes.Unavailable, "connection dropped")
+	}
+	grpcServer := grpc.NewServer(grpc.StreamInterceptor(dropStream))
+	pb.RegisterLaptopServiceServer(grpcServer, laptopServer)
+	listener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	laptopClient := client.NewLaptopClient(conn)
+
+	data := newTestImage(t, "png", 256, 200)
+	imagePath := filepath.Join(t.TempDir(), "laptop.png")
+	err = ioutil.WriteFile(imagePath, data, 0o644)
+	require.NoError(t, err)
+
+	imageID, err := laptopClient.UploadImage(laptop.GetId(), imagePath)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, atomic.LoadInt32(&streams))
+
+	reader, err := imageStore.Open(imageID)
+	require.NoError(t, err)
+	defer reader.Close()
+	saved, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, data, saved)
+
+	// a wrong checksum discards the upload
+	pbClient := pb.NewLaptopServiceClient(conn)
+	initRes, err := pbClient.InitiateUpload(context.Background(), &pb.InitiateUploadRequest{
+		Info: &pb.ImageInfo{LaptopId: laptop.GetId(), ImageType: ".png"},
+	})
+	require.NoError(t, err)
+	stream, err := pbClient.UploadChunks(context.Background())
+	require.NoError(t, err)
+	err = stream.Send(&pb.UploadChunkRequest{UploadId: initRes.GetUploadId(), ChunkData: []byte("image")})
+	require.NoError(t, err)
+	chunksRes, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	require.EqualValues(t, 5, chunksRes.GetCommittedOffset())
+
+	checksum := sha256.Sum256([]byte("other"))
+	_, err = pbClient.CommitUpload(context.Background(), &pb.CommitUploadRequest{
+		UploadId: initRes.GetUploadId(),
+		Sha256:   hex.EncodeToString(checksum[:]),
+	})
+	require.Equal(t, codes.DataLoss, status.Code(err))
+	_, err = pbClient.QueryUpload(context.Background(), &pb.QueryUploadRequest{UploadId: initRes.GetUploadId()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// the upload IDs are checked before they are part of a blob key
+	uploadID := "../images/" + initRes.GetUploadId()
+	_, err = pbClient.QueryUpload(context.Background(), &pb.QueryUploadRequest{UploadId: uploadID})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = pbClient.CommitUpload(context.Background(), &pb.CommitUploadRequest{
+		UploadId: uploadID,
+		Sha256:   hex.EncodeToString(checksum[:]),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	stream, err = pbClient.UploadChunks(context.Background())
+	require.NoError(t, err)
+	err = stream.Send(&pb.UploadChunkRequest{UploadId: uploadID, ChunkData: []byte("image")})
+	require.NoError(t, err)
+	_, err = stream.CloseAndRecv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// droppedStream fails after receiving some messages, like a stream of a dropped connection
+type droppedStream struct {
+	grpc.ServerStream
+	remaining int
+}
+
+func (stream *droppedStream) RecvMsg(m interface{}) error {
+	if stream.remaining == 0 {
+		return status.Error(codes.Unavailable, "connection dropped")
+	}
+	stream.remaining--
+	return stream.ServerStream.RecvMsg(m)
+}
+
+// uploadTestImage uploads the image data of a laptop
+func uploadTestImage(
+	t *testing.T,
+	laptopClient pb.LaptopServiceClient,
+	laptopID string,
+	imageType string,
+	data []byte,
+) *pb.UploadImageResponse {
+	stream, err := laptopClient.UploadImage(context.Background())
+	require.NoError(t, err)
+
+	err = stream.Send(&pb.UploadImageRequest{
+		Data: &pb.UploadImageRequest_Info{
+			Info: &pb.ImageInfo{LaptopId: laptopID, ImageType: imageType},
+		},
+	})
+	require.NoError(t, err)
+
+	for len(data) > 0 {
+		n := 1024
+		if n > len(data) {
+			n = len(data)
+		}
+		err = stream.Send(&pb.UploadImageRequest{
+			Data: &pb.UploadImageRequest_ChunkData{ChunkData: data[:n]},
+		})
+		require.NoError(t, err)
+		data = data[n:]
+	}
+
+	res, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	return res
 }
 
-// TestRatingLaptopClient test rating laptop
+// TestRatingLaptopClient test rating laptop, each user has one score per laptop
 func TestRatingLaptopClient(t *testing.T) {
 	t.Parallel()
 
@@ -247,39 +631,301 @@ func TestRatingLaptopClient(t *testing.T) {
 	err := laptopStore.Save(laptop)
 	require.NoError(t, err)
 
-	serverAddress := startTestLaptopServe(t, laptopStore, nil, ratingStore)
-	laptopClient := newTestLaptopClient(t, serverAddress)
-
-	stream, err := laptopClient.RateLaptop(context.Background())
+	jwtManager := NewJWTManager("secret", time.Minute)
+	interceptor := NewAuthInterceptor(jwtManager, map[string][]string{
+		"/techschool.proto.LaptopService/RateLaptop":   {"user"},
+		"/techschool.proto.LaptopService/DeleteRating": {"user"},
+	})
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(interceptor.Unary()),
+		grpc.StreamInterceptor(interceptor.Stream()),
+	)
+	pb.RegisterLaptopServiceServer(grpcServer, NewLaptopServer(laptopStore, nil, ratingStore))
+	listener, err := net.Listen("tcp", ":0")
 	require.NoError(t, err)
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+	laptopClient := newTestLaptopClient(t, listener.Addr().String())
 
-	scores := []float64{8, 7.5, 10}
-	averageScore := []float64{8, 7.75, 8.5}
+	userContext := func(username string) context.Context {
+		user, err := NewUser(username, "secret", "user")
+		require.NoError(t, err)
+		token, err := jwtManager.Generate(user)
+		require.NoError(t, err)
+		return metadata.AppendToOutgoingContext(context.Background(), "authorization", token)
+	}
+	rate := func(ctx context.Context, scores []float64) []*pb.RateLaptopResponse {
+		stream, err := laptopClient.RateLaptop(ctx)
+		require.NoError(t, err)
+		for _, score := range scores {
+			err := stream.Send(&pb.RateLaptopRequest{LaptopId: laptop.GetId(), Score: score})
+			require.NoError(t, err)
+		}
+		err = stream.CloseSend()
+		require.NoError(t, err)
 
-	n := len(scores)
-	for i := 0; i < n; i++ {
-		req := &pb.RateLaptopRequest{
-			LaptopId: laptop.GetId(),
-			Score:    scores[i],
+		responses := []*pb.RateLaptopResponse{}
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return responses
+			}
+			require.NoError(t, err)
+			require.Equal(t, laptop.GetId(), res.GetLaptopId())
+			responses = append(responses, res)
 		}
+	}
+
+	// the new scores of a user replace the previous one
+	responses := rate(userContext("user1"), []float64{8, 7.5, 10})
+	require.Len(t, responses, 3)
+	for i, average := range []float64{8, 7.5, 10} {
+		require.EqualValues(t, 1, responses[i].GetRateCount())
+		require.Equal(t, average, responses[i].GetAverageRate())
+	}
+	responses = rate(userContext("user2"), []float64{6})
+	require.EqualValues(t, 2, responses[0].GetRateCount())
+	require.Equal(t, 8.0, responses[0].GetAverageRate())
 
-		err := stream.Send(req)
+	res, err := laptopClient.DeleteRating(userContext("user1"), &pb.DeleteRatingRequest{LaptopId: laptop.GetId()})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, res.GetRateCount())
+	require.Equal(t, 6.0, res.GetAverageRate())
+	_, err = laptopClient.DeleteRating(userContext("user1"), &pb.DeleteRatingRequest{LaptopId: laptop.GetId()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// the scores out of the 1 to 10 range are rejected
+	for _, score := range []float64{0, -3, 10.5, math.NaN()} {
+		stream, err := laptopClient.RateLaptop(userContext("user1"))
+		require.NoError(t, err)
+		err = stream.Send(&pb.RateLaptopRequest{LaptopId: laptop.GetId(), Score: score})
 		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.InvalidArgument, status.Code(err), "score %v", score)
 	}
 
-	err = stream.CloseSend()
+	// the ratings of an anonymous user are rejected
+	stream, err := laptopClient.RateLaptop(context.Background())
 	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+// TestRatingWithoutUser test the ratings are rejected if the server doesn't authenticate its users
+func TestRatingWithoutUser(t *testing.T) {
+	t.Parallel()
+
+	laptopServer := NewLaptopServer(NewInMemoryLaptopStore(), nil, NewInMemoryRatingStore())
+	_, err := laptopServer.DeleteRating(context.Background(), &pb.DeleteRatingRequest{LaptopId: "laptop"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
 
-	for idx := 0; ; idx++ {
+	ctx := ContextWithUserClaims(context.Background(), &UserClaims{Username: "user1", Role: "user"})
+	_, err = laptopServer.DeleteRating(ctx, &pb.DeleteRatingRequest{LaptopId: "laptop"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// TestWatchLaptopsClient test watch laptops
+func TestWatchLaptopsClient(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := NewInMemoryLaptopStore()
+	serverAddress := startTestLaptopServe(t, laptopStore, nil, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	// the resume token of a first change lets the watch start before the next changes
+	watcher, err := laptopStore.Watch("")
+	require.NoError(t, err)
+	require.NoError(t, laptopStore.Save(sample.NewLaptop()))
+	start := <-watcher.Events()
+	watcher.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := laptopClient.WatchLaptops(ctx, &pb.WatchLaptopsRequest{
+		Filter:      &pb.Filter{MaxPriceUsd: 3000},
+		ResumeToken: start.ResumeToken,
+	})
+	require.NoError(t, err)
+
+	expensive := sample.NewLaptop()
+	expensive.PriceUsd = 4000
+	require.NoError(t, laptopStore.Save(expensive))
+
+	laptop := sample.NewLaptop()
+	laptop.PriceUsd = 2000
+	require.NoError(t, laptopStore.Save(laptop))
+	laptop.PriceUsd = 2500
+	require.NoError(t, laptopStore.Update(laptop, 0))
+	require.NoError(t, laptopStore.Delete(laptop.Id, 0))
+
+	expected := []pb.WatchLaptopsResponse_Type{
+		pb.WatchLaptopsResponse_CREATED,
+		pb.WatchLaptopsResponse_UPDATED,
+		pb.WatchLaptopsResponse_DELETED,
+	}
+	for _, eventType := range expected {
 		res, err := stream.Recv()
-		if err == io.EOF {
-			require.Equal(t, n, idx)
-			break
+		require.NoError(t, err)
+		require.Equal(t, eventType, res.GetType())
+		require.Equal(t, laptop.Id, res.GetLaptop().GetId())
+		require.NotEmpty(t, res.GetResumeToken())
+	}
+}
+
+// TestWatchLaptopsProgressClient test a filtered watch resumes after more skipped changes than the feed history
+func TestWatchLaptopsProgressClient(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := NewInMemoryLaptopStore()
+	serverAddress := startTestLaptopServe(t, laptopStore, nil, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	watcher, err := laptopStore.Watch("")
+	require.NoError(t, err)
+	require.NoError(t, laptopStore.Save(sample.NewLaptop()))
+	start := <-watcher.Events()
+	watcher.Close()
+
+	filter := &pb.Filter{MaxPriceUsd: 3000}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := laptopClient.WatchLaptops(ctx, &pb.WatchLaptopsRequest{
+		Filter:      filter,
+		ResumeToken: start.ResumeToken,
+	})
+	require.NoError(t, err)
+
+	// the skipped changes are acknowledged before the watcher buffer is full
+	resumeToken := start.ResumeToken
+	for i := 0; i <= DefaultFeedHistory/WatchProgressEvents; i++ {
+		for j := 0; j < WatchProgressEvents; j++ {
+			expensive := sample.NewLaptop()
+			expensive.PriceUsd = 4000
+			require.NoError(t, laptopStore.Save(expensive))
 		}
+		res, err := stream.Recv()
 		require.NoError(t, err)
-		require.Equal(t, laptop.GetId(), res.GetLaptopId())
-		require.Equal(t, uint32(idx+1), res.RateCount)
-		require.Equal(t, averageScore[idx], res.GetAverageRate())
+		require.Equal(t, pb.WatchLaptopsResponse_PROGRESS, res.GetType())
+		require.Nil(t, res.GetLaptop())
+		resumeToken = res.GetResumeToken()
 	}
+	cancel()
+
+	laptop := sample.NewLaptop()
+	laptop.PriceUsd = 2000
+	require.NoError(t, laptopStore.Save(laptop))
+
+	// the first token has expired, the last progress token has not
+	stream, err = laptopClient.WatchLaptops(context.Background(), &pb.WatchLaptopsRequest{
+		Filter:      filter,
+		ResumeToken: start.ResumeToken,
+	})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	stream, err = laptopClient.WatchLaptops(ctx, &pb.WatchLaptopsRequest{
+		Filter:      filter,
+		ResumeToken: resumeToken,
+	})
+	require.NoError(t, err)
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, pb.WatchLaptopsResponse_CREATED, res.GetType())
+	require.Equal(t, laptop.Id, res.GetLaptop().GetId())
+}
+
+// TestBatchCreateLaptopsClient test batch create laptops
+func TestBatchCreateLaptopsClient(t *testing.T) {
+	t.Parallel()
 
+	laptopStore := NewInMemoryLaptopStore()
+	existing := sample.NewLaptop()
+	require.NoError(t, laptopStore.Save(existing))
+
+	serverAddress := startTestLaptopServe(t, laptopStore, nil, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	invalid := sample.NewLaptop()
+	invalid.Id = "invalid-id"
+	duplicate := proto.Clone(existing).(*pb.Laptop)
+	noID := sample.NewLaptop()
+	noID.Id = ""
+
+	type testCase struct {
+		name    string
+		atomic  bool
+		laptops []*pb.Laptop
+		codes   []codes.Code
+		created uint32
+	}
+
+	testCases := []testCase{
+		{
+			name:    "partial",
+			laptops: []*pb.Laptop{sample.NewLaptop(), invalid, duplicate, noID},
+			codes:   []codes.Code{codes.OK, codes.InvalidArgument, codes.AlreadyExists, codes.OK},
+			created: 2,
+		},
+		{
+			name:    "atomic_invalid",
+			atomic:  true,
+			laptops: []*pb.Laptop{sample.NewLaptop(), invalid},
+			codes:   []codes.Code{codes.Aborted, codes.InvalidArgument},
+		},
+		{
+			name:    "atomic_duplicate",
+			atomic:  true,
+			laptops: []*pb.Laptop{sample.NewLaptop(), duplicate},
+			codes:   []codes.Code{codes.Aborted, codes.AlreadyExists},
+		},
+		{
+			name:    "atomic",
+			atomic:  true,
+			laptops: []*pb.Laptop{sample.NewLaptop(), sample.NewLaptop(), sample.NewLaptop()},
+			codes:   []codes.Code{codes.OK, codes.OK, codes.OK},
+			created: 3,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			stream, err := laptopClient.BatchCreateLaptops(context.Background())
+			require.NoError(t, err)
+
+			// one laptop per message, the mode is read from the first message
+			for i, laptop := range tc.laptops {
+				err = stream.Send(&pb.BatchCreateLaptopsRequest{
+					Atomic:  tc.atomic && i == 0,
+					Laptops: []*pb.Laptop{proto.Clone(laptop).(*pb.Laptop)},
+				})
+				require.NoError(t, err)
+			}
+			res, err := stream.CloseAndRecv()
+			require.NoError(t, err)
+			require.Equal(t, tc.created, res.GetCreatedCount())
+			require.Len(t, res.GetResults(), len(tc.laptops))
+
+			for i, result := range res.GetResults() {
+				require.Equal(t, uint32(i), result.GetIndex())
+				require.Equal(t, tc.codes[i], codes.Code(result.GetStatus().GetCode()), result.GetStatus().GetMessage())
+
+				if result.GetId() == "" || tc.codes[i] == codes.AlreadyExists {
+					continue
+				}
+				other, err := laptopStore.Find(result.GetId())
+				require.NoError(t, err)
+				if tc.codes[i] == codes.OK {
+					require.NotNil(t, other)
+					require.Equal(t, uint64(1), result.GetRevision())
+				} else {
+					require.Nil(t, other)
+				}
+			}
+		})
+	}
 }
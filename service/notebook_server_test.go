@@ -2,12 +2,24 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"library/v1/pb"
 	"library/v1/sample"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 // TestLaptopServer ..
@@ -91,3 +103,631 @@ func TestLaptopServer(t *testing.T) {
 	}
 
 }
+
+// TestLaptopServerGetDelete test get and delete laptop
+func TestLaptopServerGetDelete(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	store := NewInMemoryLaptopStore()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	server := NewLaptopServer(store, nil, nil)
+
+	res, err := server.GetLaptop(context.Background(), &pb.GetLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+	requireSameLaptop(t, laptop, res.GetLaptop())
+
+	_, err = server.DeleteLaptop(context.Background(), &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+
+	_, err = server.GetLaptop(context.Background(), &pb.GetLaptopRequest{Id: laptop.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = server.DeleteLaptop(context.Background(), &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// TestLaptopServerGetRating test the statistics of the scores of laptops
+func TestLaptopServerGetRating(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := NewInMemoryLaptopStore()
+	rated := sample.NewLaptop()
+	require.NoError(t, laptopStore.Save(rated))
+	unrated := sample.NewLaptop()
+	require.NoError(t, laptopStore.Save(unrated))
+
+	ratingStore := NewInMemoryRatingStore()
+	for i, score := range []float64{6, 8, 10, 10} {
+		_, err := ratingStore.Rate(rated.GetId(), fmt.Sprintf("user%d", i), score)
+		require.NoError(t, err)
+	}
+	server := NewLaptopServer(laptopStore, nil, ratingStore)
+
+	expected := &pb.RatingStats{
+		LaptopId:    rated.GetId(),
+		RateCount:   4,
+		AverageRate: 8.5,
+		MinScore:    6,
+		MaxScore:    10,
+		StddevScore: math.Sqrt(2.75),
+		Histogram:   []uint32{0, 0, 0, 0, 0, 1, 0, 1, 0, 2},
+	}
+	res, err := server.GetRating(context.Background(), &pb.GetRatingRequest{LaptopId: rated.GetId()})
+	require.NoError(t, err)
+	require.True(t, proto.Equal(expected, res.GetRating()))
+
+	res, err = server.GetRating(context.Background(), &pb.GetRatingRequest{LaptopId: unrated.GetId()})
+	require.NoError(t, err)
+	require.Zero(t, res.GetRating().GetRateCount())
+	require.Len(t, res.GetRating().GetHistogram(), HistogramSize)
+
+	_, err = server.GetRating(context.Background(), &pb.GetRatingRequest{LaptopId: "unknown"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	batch, err := server.BatchGetRatings(context.Background(), &pb.BatchGetRatingsRequest{
+		LaptopIds: []string{unrated.GetId(), rated.GetId(), "unknown"},
+	})
+	require.NoError(t, err)
+	require.Len(t, batch.GetRatings(), 3)
+	require.Equal(t, unrated.GetId(), batch.GetRatings()[0].GetLaptopId())
+	require.Zero(t, batch.GetRatings()[0].GetRateCount())
+	require.True(t, proto.Equal(expected, batch.GetRatings()[1]))
+	require.Equal(t, "unknown", batch.GetRatings()[2].GetLaptopId())
+	require.Zero(t, batch.GetRatings()[2].GetRateCount())
+
+	_, err = server.BatchGetRatings(context.Background(), &pb.BatchGetRatingsRequest{
+		LaptopIds: make([]string, MaxBatchRatings+1),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// the REST gateway reads the laptop ids of a batch from the repeated query parameter
+	mux := runtime.NewServeMux()
+	err = pb.RegisterLaptopServiceHandlerServer(context.Background(), mux, server)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/laptop/"+rated.GetId()+"/rating", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	restRes := &pb.GetRatingResponse{}
+	require.NoError(t, protojson.Unmarshal(recorder.Body.Bytes(), restRes))
+	require.True(t, proto.Equal(expected, restRes.GetRating()))
+
+	recorder = httptest.NewRecorder()
+	query := "laptop_ids=" + strings.Join([]string{rated.GetId(), unrated.GetId()}, "&laptop_ids=")
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/ratings?"+query, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	restBatch := &pb.BatchGetRatingsResponse{}
+	require.NoError(t, protojson.Unmarshal(recorder.Body.Bytes(), restBatch))
+	require.Len(t, restBatch.GetRatings(), 2)
+	require.True(t, proto.Equal(expected, restBatch.GetRatings()[0]))
+	require.Equal(t, unrated.GetId(), restBatch.GetRatings()[1].GetLaptopId())
+}
+
+// TestLaptopServerUpdate test update laptop with field mask
+func TestLaptopServerUpdate(t *testing.T) {
+	t.Parallel()
+
+	testCase := []struct {
+		name  string
+		paths []string
+		code  codes.Code
+	}{
+		{
+			name:  "success_top_level_field",
+			paths: []string{"price_usd"},
+			code:  codes.OK,
+		},
+		{
+			name:  "success_nested_field",
+			paths: []string{"cpu.num_cores"},
+			code:  codes.OK,
+		},
+		{
+			name:  "success_empty_mask",
+			paths: nil,
+			code:  codes.OK,
+		},
+		{
+			name:  "failure_unknown_field",
+			paths: []string{"color"},
+			code:  codes.InvalidArgument,
+		},
+		{
+			name:  "failure_not_message_field",
+			paths: []string{"price_usd.value"},
+			code:  codes.InvalidArgument,
+		},
+		{
+			name:  "failure_immutable_id",
+			paths: []string{"id"},
+			code:  codes.InvalidArgument,
+		},
+	}
+
+	for i := range testCase {
+		tc := testCase[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			laptop := sample.NewLaptop()
+			// leave room for one more core
+			laptop.Cpu.NumThreads = 16
+			store := NewInMemoryLaptopStore()
+			err := store.Save(laptop)
+			require.NoError(t, err)
+
+			server := NewLaptopServer(store, nil, nil)
+			change := sample.NewLaptop()
+			change.Id = laptop.Id
+			change.PriceUsd = laptop.PriceUsd + 100
+			change.Cpu.NumCores = laptop.Cpu.NumCores + 1
+			change.Cpu.NumThreads = 16
+
+			req := &pb.UpdateLaptopRequest{
+				Laptop:     change,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: tc.paths},
+			}
+			res, err := server.UpdateLaptop(context.Background(), req)
+			if tc.code != codes.OK {
+				require.Equal(t, tc.code, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+
+			other, err := store.Find(laptop.Id)
+			require.NoError(t, err)
+			requireSameLaptop(t, res.GetLaptop(), other)
+
+			switch tc.name {
+			case "success_top_level_field":
+				require.Equal(t, change.PriceUsd, other.PriceUsd)
+				require.Equal(t, laptop.Cpu.NumCores, other.Cpu.NumCores)
+				require.Equal(t, laptop.Brand, other.Brand)
+			case "success_nested_field":
+				require.Equal(t, change.Cpu.NumCores, other.Cpu.NumCores)
+				require.Equal(t, laptop.Cpu.Name, other.Cpu.Name)
+				require.Equal(t, laptop.PriceUsd, other.PriceUsd)
+			default:
+				require.Equal(t, change.PriceUsd, other.PriceUsd)
+				require.Equal(t, change.Brand, other.Brand)
+			}
+		})
+	}
+
+	_, err := NewLaptopServer(NewInMemoryLaptopStore(), nil, nil).UpdateLaptop(
+		context.Background(),
+		&pb.UpdateLaptopRequest{Laptop: sample.NewLaptop()},
+	)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// TestLaptopServerRevision test optimistic concurrency control of laptop updates
+func TestLaptopServerRevision(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	server := NewLaptopServer(store, nil, nil)
+
+	laptop := sample.NewLaptop()
+	created, err := server.CreateLaptop(context.Background(), &pb.CreateLaptopRequest{Laptop: laptop})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, created.GetRevision())
+
+	change := &pb.Laptop{Id: laptop.Id, PriceUsd: 1000}
+	mask := &fieldmaskpb.FieldMask{Paths: []string{"price_usd"}}
+
+	updated, err := server.UpdateLaptop(context.Background(), &pb.UpdateLaptopRequest{
+		Laptop:           change,
+		UpdateMask:       mask,
+		ExpectedRevision: 1,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, updated.GetLaptop().GetRevision())
+
+	// Stale revision from the request
+	_, err = server.UpdateLaptop(context.Background(), &pb.UpdateLaptopRequest{
+		Laptop:           change,
+		UpdateMask:       mask,
+		ExpectedRevision: 1,
+	})
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	// Stale revision from the if-match metadata
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IfMatchHeader, FormatETag(1)))
+	_, err = server.DeleteLaptop(ctx, &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(IfMatchHeader, "not-a-revision"))
+	_, err = server.DeleteLaptop(ctx, &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	found, err := server.GetLaptop(context.Background(), &pb.GetLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, found.GetLaptop().GetRevision())
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(IfMatchHeader, FormatETag(2)))
+	_, err = server.DeleteLaptop(ctx, &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+}
+
+func TestLaptopServerListLaptops(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name       string
+		descending bool
+	}
+
+	testCases := []testCase{
+		{
+			name:       "price_ascending",
+			descending: false,
+		},
+		{
+			name:       "price_descending",
+			descending: true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := NewInMemoryLaptopStore()
+			server := NewLaptopServer(store, nil, nil)
+			for i := 0; i < 7; i++ {
+				laptop := sample.NewLaptop()
+				laptop.PriceUsd = float64(1000 + i%3*100)
+				require.NoError(t, store.Save(laptop))
+			}
+
+			order := &pb.Order{Field: pb.Order_PRICE, Descending: tc.descending}
+			seen := make(map[string]bool)
+			var prices []float64
+			pageToken := ""
+			for page := 0; ; page++ {
+				res, err := server.ListLaptops(context.Background(), &pb.ListLaptopsRequest{
+					OrderBy:   order,
+					PageSize:  3,
+					PageToken: pageToken,
+				})
+				require.NoError(t, err)
+				require.LessOrEqual(t, len(res.GetLaptops()), 3)
+
+				for _, laptop := range res.GetLaptops() {
+					require.False(t, seen[laptop.GetId()])
+					seen[laptop.GetId()] = true
+					prices = append(prices, laptop.GetPriceUsd())
+				}
+
+				if page == 0 {
+					// a laptop saved before the cursor must not shift the next pages
+					laptop := sample.NewLaptop()
+					if tc.descending {
+						laptop.PriceUsd = 5000
+					} else {
+						laptop.PriceUsd = 1
+					}
+					require.NoError(t, store.Save(laptop))
+				}
+
+				pageToken = res.GetNextPageToken()
+				if pageToken == "" {
+					break
+				}
+			}
+
+			require.Len(t, seen, 7)
+			for i := 1; i < len(prices); i++ {
+				if tc.descending {
+					require.GreaterOrEqual(t, prices[i-1], prices[i])
+				} else {
+					require.LessOrEqual(t, prices[i-1], prices[i])
+				}
+			}
+		})
+	}
+}
+
+// countingRatingStore counts the reads of the ratings of a rating store
+type countingRatingStore struct {
+	RatingStore
+	finds      int
+	batchStats int
+}
+
+func (store *countingRatingStore) Find(laptopID string) (*Rating, error) {
+	store.finds++
+	return store.RatingStore.Find(laptopID)
+}
+
+func (store *countingRatingStore) BatchStats(laptopIDs []string) (map[string]*RatingStats, error) {
+	store.batchStats++
+	return store.RatingStore.BatchStats(laptopIDs)
+}
+
+// TestLaptopServerListLaptopsRating test the laptops are sorted by a snapshot of their average ratings
+func TestLaptopServerListLaptopsRating(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := NewInMemoryLaptopStore()
+	ratingStore := &countingRatingStore{RatingStore: NewInMemoryRatingStore()}
+	server := NewLaptopServer(laptopStore, nil, ratingStore)
+	for i := 0; i < 5; i++ {
+		laptop := sample.NewLaptop()
+		require.NoError(t, laptopStore.Save(laptop))
+		if i > 0 {
+			_, err := ratingStore.Rate(laptop.GetId(), "user1", float64(i*2))
+			require.NoError(t, err)
+		}
+	}
+
+	order := &pb.Order{Field: pb.Order_AVERAGE_RATING, Descending: true}
+	var averages []float64
+	pageToken := ""
+	for {
+		res, err := server.ListLaptops(context.Background(), &pb.ListLaptopsRequest{
+			OrderBy:   order,
+			PageSize:  2,
+			PageToken: pageToken,
+		})
+		require.NoError(t, err)
+		for _, laptop := range res.GetLaptops() {
+			rating, err := ratingStore.RatingStore.Find(laptop.GetId())
+			require.NoError(t, err)
+			averages = append(averages, rating.Average())
+		}
+
+		pageToken = res.GetNextPageToken()
+		if pageToken == "" {
+			break
+		}
+	}
+
+	require.Equal(t, []float64{8, 6, 4, 2, 0}, averages)
+	require.Zero(t, ratingStore.finds)
+	require.Equal(t, 3, ratingStore.batchStats)
+}
+
+// searchLaptopStream is the server stream of a SearchLaptop call, it cancels its context
+// once it sent cancelAfter laptops
+type searchLaptopStream struct {
+	grpc.ServerStream
+	ctx         context.Context
+	cancel      context.CancelFunc
+	cancelAfter int
+	laptops     []*pb.Laptop
+}
+
+func (stream *searchLaptopStream) Context() context.Context {
+	return stream.ctx
+}
+
+func (stream *searchLaptopStream) Send(res *pb.SearchLaptopResponse) error {
+	stream.laptops = append(stream.laptops, res.GetLaptop())
+	if len(stream.laptops) == stream.cancelAfter {
+		stream.cancel()
+	}
+	return nil
+}
+
+// TestLaptopServerSearchLaptopCanceled test a search without order nor page sends the laptops
+// as they are found, and stops when its context is canceled
+func TestLaptopServerSearchLaptopCanceled(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	server := NewLaptopServer(store, nil, nil)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, store.Save(sample.NewLaptop()))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &searchLaptopStream{ctx: ctx, cancel: cancel, cancelAfter: 1}
+	err := server.SearchLaptop(&pb.SearchLaptopRequest{}, stream)
+	require.Equal(t, codes.Canceled, status.Code(err))
+	require.Len(t, stream.laptops, 1)
+
+	// the laptops of an ordered search are sorted before the first is sent
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	stream = &searchLaptopStream{ctx: ctx, cancel: cancel, cancelAfter: 1}
+	err = server.SearchLaptop(&pb.SearchLaptopRequest{OrderBy: &pb.Order{Field: pb.Order_PRICE}}, stream)
+	require.NoError(t, err)
+	require.Len(t, stream.laptops, 5)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	stream = &searchLaptopStream{ctx: ctx, cancel: cancel}
+	err = server.SearchLaptop(&pb.SearchLaptopRequest{OrderBy: &pb.Order{Field: pb.Order_PRICE}}, stream)
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestLaptopServerListLaptopsInvalidToken(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	server := NewLaptopServer(store, nil, nil)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, store.Save(sample.NewLaptop()))
+	}
+
+	res, err := server.ListLaptops(context.Background(), &pb.ListLaptopsRequest{
+		OrderBy:  &pb.Order{Field: pb.Order_PRICE},
+		PageSize: 1,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.GetNextPageToken())
+
+	_, err = server.ListLaptops(context.Background(), &pb.ListLaptopsRequest{
+		OrderBy:   &pb.Order{Field: pb.Order_RAM},
+		PageSize:  1,
+		PageToken: res.GetNextPageToken(),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = server.ListLaptops(context.Background(), &pb.ListLaptopsRequest{
+		PageToken: "not a token",
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestLaptopServerListLaptopsQuery(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	server := NewLaptopServer(store, nil, nil)
+
+	cheap := sample.NewLaptop()
+	cheap.PriceUsd = 1000
+	require.NoError(t, store.Save(cheap))
+	expensive := sample.NewLaptop()
+	expensive.PriceUsd = 3000
+	require.NoError(t, store.Save(expensive))
+
+	res, err := server.ListLaptops(context.Background(), &pb.ListLaptopsRequest{
+		Query: "price_usd < 2000 && ram > 0B",
+	})
+	require.NoError(t, err)
+	require.Len(t, res.GetLaptops(), 1)
+	require.Equal(t, cheap.Id, res.GetLaptops()[0].GetId())
+
+	// the brands are compared without case
+	res, err = server.ListLaptops(context.Background(), &pb.ListLaptopsRequest{
+		Query: fmt.Sprintf("price_usd < 2000 && brand in [%q]", strings.ToUpper(cheap.Brand)),
+	})
+	require.NoError(t, err)
+	require.Len(t, res.GetLaptops(), 1)
+	require.Equal(t, cheap.Id, res.GetLaptops()[0].GetId())
+
+	_, err = server.ListLaptops(context.Background(), &pb.ListLaptopsRequest{
+		Query: "price_usd < ",
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "1:13")
+
+	_, err = server.ListLaptops(context.Background(), &pb.ListLaptopsRequest{
+		Filter: &pb.Filter{MaxPriceUsd: 2000},
+		Query:  "price_usd < 2000",
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestLaptopServerAggregateLaptops(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	server := NewLaptopServer(store, nil, nil)
+
+	newLaptop := func(brand string, price float64, ramGB uint64, panel pb.Screen_Panel) {
+		laptop := sample.NewLaptop()
+		laptop.Brand = brand
+		laptop.PriceUsd = price
+		laptop.Ram = &pb.Memory{Value: ramGB * 1024, Unit: pb.Memory_MEGABYTE}
+		laptop.Screen.Panel = panel
+		laptop.Gpus = []*pb.GPU{{Brand: "NVIDIA"}, {Brand: "NVIDIA"}, {Brand: "AMD"}}
+		require.NoError(t, store.Save(laptop))
+	}
+	newLaptop("Dell", 900, 8, pb.Screen_IPS)
+	newLaptop("Dell", 1200, 16, pb.Screen_IPS)
+	newLaptop("Apple", 2500, 16, pb.Screen_OLED)
+	newLaptop("Lenovo", 3100, 32, pb.Screen_IPS)
+
+	res, err := server.AggregateLaptops(context.Background(), &pb.AggregateLaptopsRequest{
+		Filter: &pb.Filter{MinPriceUsd: 1000},
+		Facets: []*pb.Facet{
+			{Field: "brand", Type: &pb.Facet_Terms{Terms: &pb.TermsFacet{Size: 2}}},
+			{Name: "gpu", Field: "gpus.brand"},
+			{Field: "screen.panel"},
+			{Field: "ram"},
+			{Field: "price_usd", Type: &pb.Facet_Histogram{Histogram: &pb.HistogramFacet{Interval: 1000}}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), res.GetTotal())
+
+	buckets := func(result *pb.FacetResult) map[string]uint64 {
+		counts := make(map[string]uint64)
+		for _, bucket := range result.GetBuckets() {
+			counts[bucket.GetKey()] = bucket.GetCount()
+		}
+		return counts
+	}
+
+	facets := res.GetFacets()
+	require.Len(t, facets, 5)
+	require.Equal(t, "brand", facets[0].GetName())
+	require.Len(t, facets[0].GetBuckets(), 2)
+	require.Equal(t, uint64(1), facets[0].GetOtherCount())
+	require.Equal(t, map[string]uint64{"NVIDIA": 3, "AMD": 3}, buckets(facets[1]))
+	require.Equal(t, map[string]uint64{"IPS": 2, "OLED": 1}, buckets(facets[2]))
+	require.Equal(t, map[string]uint64{"16GB": 2, "32GB": 1}, buckets(facets[3]))
+	require.Equal(t, map[string]uint64{"1000": 1, "2000": 1, "3000": 1}, buckets(facets[4]))
+	require.Equal(t, "1000", facets[4].GetBuckets()[0].GetKey())
+
+	res, err = server.AggregateLaptops(context.Background(), &pb.AggregateLaptopsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), res.GetTotal())
+	require.Len(t, res.GetFacets(), len(defaultFacets()))
+	price := res.GetFacets()[3]
+	require.Equal(t, "price_usd", price.GetName())
+	require.Equal(t, "*-1000", price.GetBuckets()[0].GetKey())
+	require.Equal(t, uint64(1), price.GetBuckets()[0].GetCount())
+	require.Equal(t, "3000-*", price.GetBuckets()[4].GetKey())
+	require.Equal(t, uint64(1), price.GetBuckets()[4].GetCount())
+
+	invalid := []*pb.Facet{
+		{Field: "color"},
+		{Field: "cpu"},
+		{Field: "brand", Type: &pb.Facet_Range{Range: &pb.RangeFacet{}}},
+		{Field: "price_usd", Type: &pb.Facet_Histogram{Histogram: &pb.HistogramFacet{}}},
+	}
+	for _, facet := range invalid {
+		_, err = server.AggregateLaptops(context.Background(), &pb.AggregateLaptopsRequest{
+			Facets: []*pb.Facet{facet},
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err), "facet: %v", facet)
+	}
+}
+
+func TestLaptopServerListLaptopsText(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	server := NewLaptopServer(store, nil, nil)
+
+	names := []string{"ThinkPad P1", "ThinkPad ThinkPad", "XPS 13"}
+	for i, name := range names {
+		laptop := sample.NewLaptop()
+		laptop.Id = names[i]
+		laptop.Brand = "Lenovo"
+		laptop.Name = name
+		laptop.PriceUsd = float64(3000 - i*100)
+		require.NoError(t, store.Save(laptop))
+	}
+
+	res, err := server.ListLaptops(context.Background(), &pb.ListLaptopsRequest{Text: "thinkp"})
+	require.NoError(t, err)
+	require.Len(t, res.GetLaptops(), 2)
+	require.Equal(t, "ThinkPad ThinkPad", res.GetLaptops()[0].GetId())
+
+	res, err = server.ListLaptops(context.Background(), &pb.ListLaptopsRequest{
+		Text:    "thinkpad",
+		OrderBy: &pb.Order{Field: pb.Order_PRICE},
+		Filter:  &pb.Filter{MaxPriceUsd: 2950},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.GetLaptops(), 1)
+	require.Equal(t, "ThinkPad ThinkPad", res.GetLaptops()[0].GetId())
+
+	_, err = server.ListLaptops(context.Background(), &pb.ListLaptopsRequest{
+		OrderBy: &pb.Order{Field: pb.Order_RELEVANCE},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
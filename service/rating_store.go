@@ -1,11 +1,26 @@
 package service
 
-import "sync"
+import (
+	"math"
+	"sort"
+	"sync"
+)
 
-// RatingStore is a interface to store laptop rating
+// RatingStore is a interface to store laptop rating, each user has one score per laptop
 type RatingStore interface {
-	// Add a new laptop score to the store and returns its rating
-	Add(laptopID string, score float64) (*Rating, error)
+	// Rate saves the score of a user for a laptop, replacing the previous score of the user,
+	// and returns the rating of the laptop
+	Rate(laptopID string, username string, score float64) (*Rating, error)
+	// Delete removes the score of a user for a laptop and returns the rating of the laptop,
+	// it returns ErrNotFound if the user didn't rate the laptop
+	Delete(laptopID string, username string) (*Rating, error)
+	// Find returns the rating of a laptop, or nil if it isn't rated
+	Find(laptopID string) (*Rating, error)
+	// Stats returns the statistics of the scores of a laptop, or nil if it isn't rated
+	Stats(laptopID string) (*RatingStats, error)
+	// BatchStats returns the statistics of the scores of many laptops by laptop ID,
+	// the laptops that aren't rated are not in the map
+	BatchStats(laptopIDs []string) (map[string]*RatingStats, error)
 }
 
 // Rating contains the rating information of laptop
@@ -14,37 +29,195 @@ type Rating struct {
 	Sum   float64
 }
 
+// MinScore and MaxScore bound the scores of the laptops
+const (
+	MinScore = 1
+	MaxScore = 10
+)
+
+// HistogramSize is the number of buckets of a score histogram, one for each score from 1 to 10
+const HistogramSize = 10
+
+// RatingStats summarizes the scores of a laptop
+type RatingStats struct {
+	Count   uint32
+	Average float64
+	Min     float64
+	Max     float64
+	// StdDev is the population standard deviation of the scores
+	StdDev float64
+	// Histogram counts the scores rounded to the nearest integer, Histogram[0] counts the scores of 1,
+	// the scores out of the 1 to 10 range are counted in the first or last bucket
+	Histogram [HistogramSize]uint32
+	// LegacyCount and LegacyAverage summarize the scores stored without their user by a previous version,
+	// they are not part of the other statistics
+	LegacyCount   uint32
+	LegacyAverage float64
+}
+
+// TotalAverage returns the average of the scores with the legacy scores
+func (stats *RatingStats) TotalAverage() float64 {
+	count := stats.Count + stats.LegacyCount
+	if count == 0 {
+		return 0
+	}
+	return (stats.Average*float64(stats.Count) + stats.LegacyAverage*float64(stats.LegacyCount)) / float64(count)
+}
+
+// newRatingStats returns the statistics of scores, or nil if there is none
+func newRatingStats(scores []float64) *RatingStats {
+	if len(scores) == 0 {
+		return nil
+	}
+
+	stats := &RatingStats{
+		Count: uint32(len(scores)),
+		Min:   scores[0],
+		Max:   scores[0],
+	}
+	sum := 0.0
+	for _, score := range scores {
+		sum += score
+		stats.Min = math.Min(stats.Min, score)
+		stats.Max = math.Max(stats.Max, score)
+
+		bucket := int(math.Round(score)) - 1
+		if bucket < 0 {
+			bucket = 0
+		}
+		if bucket >= HistogramSize {
+			bucket = HistogramSize - 1
+		}
+		stats.Histogram[bucket]++
+	}
+	stats.Average = sum / float64(len(scores))
+
+	// the deviation is computed from the average, not from the sum of the squares that loses precision
+	variance := 0.0
+	for _, score := range scores {
+		variance += (score - stats.Average) * (score - stats.Average)
+	}
+	stats.StdDev = math.Sqrt(variance / float64(len(scores)))
+	return stats
+}
+
 // InMemoryRatingStore is store the laptop rating
 type InMemoryRatingStore struct {
 	mutex  sync.RWMutex
 	rating map[string]*Rating
+	// scores are the scores of each laptop by username
+	scores map[string]map[string]float64
 }
 
 // NewInMemoryRatingStore return *InMemoryRatingStore
 func NewInMemoryRatingStore() *InMemoryRatingStore {
 	return &InMemoryRatingStore{
 		rating: make(map[string]*Rating),
+		scores: make(map[string]map[string]float64),
 	}
 }
 
-func (store *InMemoryRatingStore) Add(laptopID string, score float64) (*Rating, error) {
+func (store *InMemoryRatingStore) Rate(laptopID string, username string, score float64) (*Rating, error) {
 	// used a lock
 	store.mutex.Lock()
 	defer store.mutex.Unlock()
 
-	// Detect if laptopID exists and update rating
+	scores := store.scores[laptopID]
+	if scores == nil {
+		scores = make(map[string]float64)
+		store.scores[laptopID] = scores
+	}
 	rat := store.rating[laptopID]
 	if rat == nil {
-		rat = &Rating{
-			Count: 1,
-			Sum:   score,
-		}
+		rat = &Rating{}
+		store.rating[laptopID] = rat
+	}
+
+	// a new score of the user replaces the previous one
+	previous, rated := scores[username]
+	if rated {
+		rat.Sum += score - previous
 	} else {
 		rat.Count++
 		rat.Sum += score
 	}
+	scores[username] = score
+
+	return &Rating{Count: rat.Count, Sum: rat.Sum}, nil
+}
+
+func (store *InMemoryRatingStore) Delete(laptopID string, username string) (*Rating, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	previous, rated := store.scores[laptopID][username]
+	if !rated {
+		return nil, ErrNotFound
+	}
+	delete(store.scores[laptopID], username)
+
+	rat := store.rating[laptopID]
+	rat.Count--
+	rat.Sum -= previous
+	if rat.Count == 0 {
+		delete(store.rating, laptopID)
+		delete(store.scores, laptopID)
+		return &Rating{}, nil
+	}
+
+	return &Rating{Count: rat.Count, Sum: rat.Sum}, nil
+}
+
+// Find implement the RatingStore interface
+func (store *InMemoryRatingStore) Find(laptopID string) (*Rating, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	rat := store.rating[laptopID]
+	if rat == nil {
+		return nil, nil
+	}
 
-	store.rating[laptopID] = rat
+	return &Rating{Count: rat.Count, Sum: rat.Sum}, nil
+}
+
+// Average returns the average score of the rating
+func (rating *Rating) Average() float64 {
+	if rating == nil || rating.Count == 0 {
+		return 0
+	}
+	return rating.Sum / float64(rating.Count)
+}
+
+// Stats implement the RatingStore interface
+func (store *InMemoryRatingStore) Stats(laptopID string) (*RatingStats, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	return store.stats(laptopID), nil
+}
 
-	return rat, nil
+// BatchStats implement the RatingStore interface
+func (store *InMemoryRatingStore) BatchStats(laptopIDs []string) (map[string]*RatingStats, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	stats := make(map[string]*RatingStats, len(laptopIDs))
+	for _, laptopID := range laptopIDs {
+		if laptopStats := store.stats(laptopID); laptopStats != nil {
+			stats[laptopID] = laptopStats
+		}
+	}
+	return stats, nil
+}
+
+// stats returns the statistics of the scores of a laptop, the caller holds the mutex
+func (store *InMemoryRatingStore) stats(laptopID string) *RatingStats {
+	scores := make([]float64, 0, len(store.scores[laptopID]))
+	for _, score := range store.scores[laptopID] {
+		scores = append(scores, score)
+	}
+	// the scores are summed in the same order whatever the order of the map
+	sort.Float64s(scores)
+	return newRatingStats(scores)
 }
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestInMemoryRatingStore(t *testing.T) {
+	t.Parallel()
+
+	requireRatingStore(t, NewInMemoryRatingStore())
+}
+
+// requireRatingStore checks that a store keeps one score per user and laptop
+func requireRatingStore(t *testing.T, store RatingStore) {
+	rating, err := store.Rate("laptop", "user1", 8)
+	require.NoError(t, err)
+	require.Equal(t, &Rating{Count: 1, Sum: 8}, rating)
+	rating, err = store.Rate("laptop", "user2", 7)
+	require.NoError(t, err)
+	require.Equal(t, &Rating{Count: 2, Sum: 15}, rating)
+
+	// a new score replaces the previous score of the user
+	rating, err = store.Rate("laptop", "user1", 10)
+	require.NoError(t, err)
+	require.Equal(t, &Rating{Count: 2, Sum: 17}, rating)
+	rating, err = store.Rate("other", "user1", 3)
+	require.NoError(t, err)
+	require.Equal(t, &Rating{Count: 1, Sum: 3}, rating)
+
+	stats, err := store.Stats("laptop")
+	require.NoError(t, err)
+	require.Equal(t, &RatingStats{
+		Count:     2,
+		Average:   8.5,
+		Min:       7,
+		Max:       10,
+		StdDev:    1.5,
+		Histogram: [HistogramSize]uint32{6: 1, 9: 1},
+	}, stats)
+	batch, err := store.BatchStats([]string{"laptop", "other", "unknown"})
+	require.NoError(t, err)
+	require.Len(t, batch, 2)
+	require.Equal(t, stats, batch["laptop"])
+	require.Equal(t, uint32(1), batch["other"].Histogram[2])
+
+	rating, err = store.Delete("laptop", "user2")
+	require.NoError(t, err)
+	require.Equal(t, &Rating{Count: 1, Sum: 10}, rating)
+	_, err = store.Delete("laptop", "user2")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	rating, err = store.Delete("laptop", "user1")
+	require.NoError(t, err)
+	require.Zero(t, rating.Count)
+	rating, err = store.Find("laptop")
+	require.NoError(t, err)
+	require.Nil(t, rating)
+	stats, err = store.Stats("laptop")
+	require.NoError(t, err)
+	require.Nil(t, stats)
+
+	rating, err = store.Find("other")
+	require.NoError(t, err)
+	require.Equal(t, &Rating{Count: 1, Sum: 3}, rating)
+}
+
+func TestNewRatingStats(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		scores []float64
+		stats  *RatingStats
+	}{
+		{
+			name:   "no_score",
+			scores: nil,
+			stats:  nil,
+		},
+		{
+			name:   "one_score",
+			scores: []float64{4},
+			stats:  &RatingStats{Count: 1, Average: 4, Min: 4, Max: 4, Histogram: [HistogramSize]uint32{3: 1}},
+		},
+		{
+			name:   "rounded_scores",
+			scores: []float64{2.4, 2.6, 3, 9.5},
+			stats:  &RatingStats{Count: 4, Average: 4.375, Min: 2.4, StdDev: math.Sqrt(8.801875), Max: 9.5, Histogram: [HistogramSize]uint32{1: 1, 2: 2, 9: 1}},
+		},
+		{
+			name:   "out_of_range_scores",
+			scores: []float64{-3, 0, 12},
+			stats:  &RatingStats{Count: 3, Average: 3, Min: -3, StdDev: math.Sqrt(42), Max: 12, Histogram: [HistogramSize]uint32{0: 2, 9: 1}},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			stats := newRatingStats(tc.scores)
+			if tc.stats == nil {
+				require.Nil(t, stats)
+				return
+			}
+			require.InDelta(t, tc.stats.Average, stats.Average, 1e-9)
+			require.InDelta(t, tc.stats.StdDev, stats.StdDev, 1e-9)
+			stats.Average = tc.stats.Average
+			stats.StdDev = tc.stats.StdDev
+			require.Equal(t, tc.stats, stats)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ETagHeader is the response metadata key that carries the laptop revision
+	ETagHeader = "etag"
+	// IfMatchHeader is the request metadata key that carries the expected laptop revision
+	IfMatchHeader = "if-match"
+)
+
+// FormatETag returns the quoted entity tag of a revision
+func FormatETag(revision uint64) string {
+	return strconv.Quote(strconv.FormatUint(revision, 10))
+}
+
+// ParseETag returns the revision of a quoted entity tag
+func ParseETag(etag string) (uint64, error) {
+	value := strings.TrimSpace(etag)
+	unquoted, err := strconv.Unquote(value)
+	if err == nil {
+		value = unquoted
+	}
+
+	revision, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid entity tag %s", etag)
+	}
+	return revision, nil
+}
+
+// expectedRevision returns the revision requested by the client,
+// it falls back to the if-match metadata when the request doesn't carry one
+func expectedRevision(ctx context.Context, revision uint64) (uint64, error) {
+	if revision != 0 {
+		return revision, nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, nil
+	}
+	values := md.Get(IfMatchHeader)
+	if len(values) == 0 || values[0] == "*" {
+		return 0, nil
+	}
+
+	revision, err := ParseETag(values[0])
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "cannot parse %s header: %v", IfMatchHeader, err)
+	}
+	return revision, nil
+}
+
+// sendRevisionHeader sends the revision to the client as an etag header
+func sendRevisionHeader(ctx context.Context, revision uint64) {
+	if grpc.ServerTransportStreamFromContext(ctx) == nil {
+		return
+	}
+
+	err := grpc.SetHeader(ctx, metadata.Pairs(ETagHeader, FormatETag(revision)))
+	if err != nil {
+		log.Printf("cannot send %s header: %v", ETagHeader, err)
+	}
+}
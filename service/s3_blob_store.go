@@ -0,0 +1,414 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MinS3PartSize is the smallest size of the parts of an S3 multipart upload, except the last one
+const MinS3PartSize = 5 << 20
+
+// DefaultS3Timeout is the time limit of the requests of the default client, the reads of the blobs included
+const DefaultS3Timeout = time.Minute
+
+// S3Config configures an S3BlobStore
+type S3Config struct {
+	// Endpoint is the URL of the S3 compatible service, e.g. https://s3.eu-west-1.amazonaws.com
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// Prefix is added to the keys of the blobs
+	Prefix string
+	// PartSize is the size of the parts of the multipart uploads, the blobs smaller than a part are put at once.
+	// It is at least MinS3PartSize
+	PartSize int64
+	// Client sends the requests, a client with DefaultS3Timeout if nil
+	Client *http.Client
+}
+
+// S3BlobStore stores blobs as the objects of an S3 compatible bucket, addressed with path-style URLs
+type S3BlobStore struct {
+	config   S3Config
+	endpoint *url.URL
+	signer   s3Signer
+}
+
+// NewS3BlobStore returns a new S3BlobStore
+func NewS3BlobStore(config S3Config) (*S3BlobStore, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint: %q", config.Endpoint)
+	}
+	if config.Bucket == "" {
+		return nil, errors.New("S3 bucket is required")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	if config.PartSize < MinS3PartSize {
+		config.PartSize = MinS3PartSize
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: DefaultS3Timeout}
+	}
+
+	return &S3BlobStore{
+		config:   config,
+		endpoint: endpoint,
+		signer: s3Signer{
+			accessKeyID:     config.AccessKeyID,
+			secretAccessKey: config.SecretAccessKey,
+			region:          config.Region,
+		},
+	}, nil
+}
+
+// s3Error is the body of the error responses
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func (store *S3BlobStore) objectURL(key string, query url.Values) *url.URL {
+	objectURL := *store.endpoint
+	objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + store.config.Bucket
+	if key != "" {
+		objectURL.Path += "/" + store.config.Prefix + key
+	}
+	objectURL.RawPath = ""
+	objectURL.RawQuery = query.Encode()
+	return &objectURL
+}
+
+// do sends a signed request and returns its response if its status is expected
+func (store *S3BlobStore) do(method string, objectURL *url.URL, body []byte, expected ...int) (*http.Response, error) {
+	req, err := http.NewRequest(method, objectURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	checksum := sha256.Sum256(body)
+	store.signer.sign(req, hex.EncodeToString(checksum[:]), time.Now())
+
+	res, err := store.config.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range expected {
+		if res.StatusCode == code {
+			return res, nil
+		}
+	}
+	defer res.Body.Close()
+
+	var s3Err s3Error
+	data, _ := ioutil.ReadAll(io.LimitReader(res.Body, 64<<10))
+	if xml.Unmarshal(data, &s3Err) != nil || s3Err.Code == "" {
+		s3Err.Code = res.Status
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s %s", ErrNotFound, s3Err.Code, s3Err.Message)
+	}
+	return nil, fmt.Errorf("S3 %s %s failed: %s %s", method, objectURL.Path, s3Err.Code, s3Err.Message)
+}
+
+func (store *S3BlobStore) Put(key string, data io.Reader) error {
+	// a blob smaller than a part is put at once, the larger ones are sent in parts
+	part, err := readPart(data, store.config.PartSize)
+	if err != nil {
+		return fmt.Errorf("cannot read blob: %w", err)
+	}
+	if int64(len(part)) < store.config.PartSize {
+		res, err := store.do(http.MethodPut, store.objectURL(key, nil), part, http.StatusOK)
+		if err != nil {
+			return fmt.Errorf("cannot put blob: %w", err)
+		}
+		res.Body.Close()
+		return nil
+	}
+
+	uploadID, err := store.createMultipartUpload(key)
+	if err != nil {
+		return err
+	}
+	err = store.uploadParts(key, uploadID, part, data)
+	if err != nil {
+		// the parts of an aborted upload are removed, it is not an error if it fails
+		res, abortErr := store.do(http.MethodDelete, store.objectURL(key, url.Values{"uploadId": {uploadID}}), nil, http.StatusNoContent)
+		if abortErr == nil {
+			res.Body.Close()
+		}
+		return err
+	}
+	return nil
+}
+
+// readPart reads size bytes, less at the end of data
+func readPart(data io.Reader, size int64) ([]byte, error) {
+	return ioutil.ReadAll(io.LimitReader(data, size))
+}
+
+type s3InitiateMultipartUploadResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+type s3CompletedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+type s3CompleteMultipartUpload struct {
+	XMLName xml.Name          `xml:"CompleteMultipartUpload"`
+	Parts   []s3CompletedPart `xml:"Part"`
+}
+
+func (store *S3BlobStore) createMultipartUpload(key string) (string, error) {
+	res, err := store.do(http.MethodPost, store.objectURL(key, url.Values{"uploads": {""}}), nil, http.StatusOK)
+	if err != nil {
+		return "", fmt.Errorf("cannot create multipart upload: %w", err)
+	}
+	defer res.Body.Close()
+
+	var result s3InitiateMultipartUploadResult
+	err = xml.NewDecoder(res.Body).Decode(&result)
+	if err != nil || result.UploadID == "" {
+		return "", fmt.Errorf("cannot read multipart upload: %v", err)
+	}
+	return result.UploadID, nil
+}
+
+// uploadParts sends the first part and the next ones read from data, then completes the upload
+func (store *S3BlobStore) uploadParts(key string, uploadID string, part []byte, data io.Reader) error {
+	complete := s3CompleteMultipartUpload{}
+	for number := 1; len(part) > 0; number++ {
+		query := url.Values{
+			"partNumber": {strconv.Itoa(number)},
+			"uploadId":   {uploadID},
+		}
+		res, err := store.do(http.MethodPut, store.objectURL(key, query), part, http.StatusOK)
+		if err != nil {
+			return fmt.Errorf("cannot upload part %d: %w", number, err)
+		}
+		res.Body.Close()
+		complete.Parts = append(complete.Parts, s3CompletedPart{PartNumber: number, ETag: res.Header.Get("ETag")})
+
+		part, err = readPart(data, store.config.PartSize)
+		if err != nil {
+			return fmt.Errorf("cannot read blob: %w", err)
+		}
+	}
+
+	body, err := xml.Marshal(complete)
+	if err != nil {
+		return err
+	}
+	res, err := store.do(http.MethodPost, store.objectURL(key, url.Values{"uploadId": {uploadID}}), body, http.StatusOK)
+	if err != nil {
+		return fmt.Errorf("cannot complete multipart upload: %w", err)
+	}
+	defer res.Body.Close()
+
+	// the upload may still fail after the status is sent, the error is in the body
+	var s3Err s3Error
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("cannot complete multipart upload: %w", err)
+	}
+	if xml.Unmarshal(body, &s3Err) == nil && s3Err.Code != "" {
+		return fmt.Errorf("cannot complete multipart upload: %s %s", s3Err.Code, s3Err.Message)
+	}
+	return nil
+}
+
+func (store *S3BlobStore) Get(key string) (io.ReadCloser, error) {
+	res, err := store.do(http.MethodGet, store.objectURL(key, nil), nil, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get blob: %w", err)
+	}
+	return res.Body, nil
+}
+
+func (store *S3BlobStore) Delete(key string) error {
+	res, err := store.do(http.MethodDelete, store.objectURL(key, nil), nil, http.StatusNoContent, http.StatusOK)
+	if err != nil {
+		return fmt.Errorf("cannot delete blob: %w", err)
+	}
+	res.Body.Close()
+	return nil
+}
+
+type s3ListBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (store *S3BlobStore) List(prefix string) ([]string, error) {
+	keys := []string{}
+	token := ""
+	for {
+		query := url.Values{
+			"list-type": {"2"},
+			"prefix":    {store.config.Prefix + prefix},
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		res, err := store.do(http.MethodGet, store.objectURL("", query), nil, http.StatusOK)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list blobs: %w", err)
+		}
+		var result s3ListBucketResult
+		err = xml.NewDecoder(res.Body).Decode(&result)
+		res.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read blob list: %w", err)
+		}
+
+		for _, content := range result.Contents {
+			keys = append(keys, strings.TrimPrefix(content.Key, store.config.Prefix))
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// s3Signer signs the requests with AWS Signature Version 4
+type s3Signer struct {
+	accessKeyID     string
+	secretAccessKey string
+	region          string
+}
+
+const (
+	s3SigningAlgorithm = "AWS4-HMAC-SHA256"
+	s3DateFormat       = "20060102T150405Z"
+)
+
+// sign adds the date, the payload hash and the authorization headers to req
+func (signer s3Signer) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format(s3DateFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := s3SignedHeaders(req)
+	scope := signer.scope(amzDate)
+	signature := signer.signature(req, signedHeaders, payloadHash, amzDate)
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SigningAlgorithm, signer.accessKeyID, scope, strings.Join(signedHeaders, ";"), signature,
+	))
+}
+
+func (signer s3Signer) scope(amzDate string) string {
+	return amzDate[:8] + "/" + signer.region + "/s3/aws4_request"
+}
+
+// signature returns the signature of a request for the given signed headers, in lower case
+func (signer s3Signer) signature(req *http.Request, signedHeaders []string, payloadHash string, amzDate string) string {
+	canonicalHeaders := strings.Builder{}
+	for _, name := range signedHeaders {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = requestHost(req)
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3URIEncode(req.URL.Path, false),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3SigningAlgorithm,
+		amzDate,
+		signer.scope(amzDate),
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+signer.secretAccessKey), amzDate[:8])
+	key = hmacSHA256(key, signer.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// s3SignedHeaders returns the sorted names of the headers to sign: the host, the range,
+// the content type and the x-amz-* headers
+func s3SignedHeaders(req *http.Request) []string {
+	names := []string{"host"}
+	for name := range req.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, "x-amz-") || name == "range" || name == "content-type" || name == "content-md5" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func requestHost(req *http.Request) string {
+	if req.Host != "" {
+		return req.Host
+	}
+	return req.URL.Host
+}
+
+func s3CanonicalQuery(query url.Values) string {
+	params := []string{}
+	for name, values := range query {
+		for _, value := range values {
+			params = append(params, s3URIEncode(name, true)+"="+s3URIEncode(value, true))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// s3URIEncode escapes every byte except the unreserved characters, and the slashes unless encodeSlash
+func s3URIEncode(value string, encodeSlash bool) string {
+	encoded := strings.Builder{}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		unreserved := 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~'
+		if unreserved || c == '/' && !encodeSlash {
+			encoded.WriteByte(c)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", c)
+		}
+	}
+	return encoded.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,404 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"library/v1/pb"
+	"strings"
+	"sync"
+)
+
+// SQLLaptopStore stores laptops in a relational database,
+// the columns used by Filter are indexed next to the protobuf data of the laptop.
+// Its watchers receive the changes made through the store, not by other database clients,
+// and their resume tokens expire when the store restarts: the feed is not in the database
+// since the changes of the other clients would be missing from it anyway.
+type SQLLaptopStore struct {
+	db    *sql.DB
+	feed  *ChangeFeed
+	mutex sync.Mutex // serializes the commits with the publication of their changes
+}
+
+// NewSQLLaptopStore returns a laptop store of a migrated database
+func NewSQLLaptopStore(db *sql.DB) *SQLLaptopStore {
+	return &SQLLaptopStore{
+		db:   db,
+		feed: NewChangeFeed(DefaultFeedHistory, DefaultWatcherBuffer),
+	}
+}
+
+// Save implement the LaptopStore interface
+func (store *SQLLaptopStore) Save(laptop *pb.Laptop) error {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = insertLaptop(tx, laptop)
+	if err != nil {
+		return err
+	}
+
+	err = store.commit(tx, func() {
+		store.feed.publish(pb.WatchLaptopsResponse_CREATED, proto.Clone(laptop).(*pb.Laptop))
+	})
+	if err != nil {
+		return fmt.Errorf("cannot commit laptop: %w", err)
+	}
+	return nil
+}
+
+// SaveAll implement the LaptopStore interface, the batch is saved in one transaction
+func (store *SQLLaptopStore) SaveAll(laptops []*pb.Laptop) error {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i, laptop := range laptops {
+		err = insertLaptop(tx, laptop)
+		if err != nil {
+			return &SaveError{Index: i, Err: err}
+		}
+	}
+
+	err = store.commit(tx, func() {
+		for _, laptop := range laptops {
+			store.feed.publish(pb.WatchLaptopsResponse_CREATED, proto.Clone(laptop).(*pb.Laptop))
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("cannot commit laptops: %w", err)
+	}
+	return nil
+}
+
+// insertLaptop inserts a new laptop with revision 1, the insert does nothing if the ID exists
+// so that a laptop saved by a concurrent transaction is reported as ErrAlreadyExists
+func insertLaptop(tx *sql.Tx, laptop *pb.Laptop) error {
+	revision := laptop.Revision
+	laptop.Revision = 1
+	data, err := proto.Marshal(laptop)
+	if err != nil {
+		return fmt.Errorf("cannot marshal laptop: %w", err)
+	}
+
+	result, err := tx.Exec(
+		`INSERT INTO laptops (id, revision, price_usd, cpu_num_cores, cpu_min_ghz, ram_bits, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		laptop.Id,
+		laptop.Revision,
+		laptop.GetPriceUsd(),
+		laptop.GetCpu().GetNumCores(),
+		laptop.GetCpu().GetMinGhz(),
+		int64(toBit(laptop.GetRam())),
+		data,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot insert laptop: %w", err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("cannot insert laptop: %w", err)
+	}
+	if inserted == 0 {
+		laptop.Revision = revision
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// Find implement the LaptopStore interface
+func (store *SQLLaptopStore) Find(id string) (*pb.Laptop, error) {
+	var data []byte
+	err := store.db.QueryRow("SELECT data FROM laptops WHERE id = ?", id).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot select laptop: %w", err)
+	}
+
+	return unmarshalLaptop(data)
+}
+
+// Search implement the LaptopStore interface, the indexed columns narrow
+// the candidates in SQL and isQualified checks the rest of the filter
+func (store *SQLLaptopStore) Search(ctx context.Context, filter *pb.Filter, found func(laptop *pb.Laptop) error) error {
+	where, args := filterConditions(filter)
+	query := "SELECT data FROM laptops"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	rows, err := store.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("cannot select laptops: %w", err)
+	}
+
+	// Read every row before calling found, so a slow callback doesn't hold the connection
+	var laptops []*pb.Laptop
+	for rows.Next() {
+		var data []byte
+		err = rows.Scan(&data)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("cannot scan laptop: %w", err)
+		}
+		laptop, err := unmarshalLaptop(data)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		laptops = append(laptops, laptop)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return fmt.Errorf("cannot select laptops: %w", err)
+	}
+
+	for _, laptop := range laptops {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !isQualified(filter, laptop) {
+			continue
+		}
+		err = found(laptop)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SearchText implement the LaptopStore interface, the laptops that match filter
+// are indexed for each search, so their scores only depend on each other
+func (store *SQLLaptopStore) SearchText(
+	ctx context.Context,
+	filter *pb.Filter,
+	text string,
+	found func(laptop *pb.Laptop, score float64) error,
+) error {
+	index := newTextIndex()
+	var laptops []*pb.Laptop
+	err := store.Search(ctx, filter, func(laptop *pb.Laptop) error {
+		index.insert(laptop)
+		laptops = append(laptops, laptop)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	scores := index.search(text)
+	for _, laptop := range laptops {
+		score, ok := scores[laptop.Id]
+		if !ok {
+			continue
+		}
+		err = found(laptop, score)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Aggregate implement the LaptopStore interface
+func (store *SQLLaptopStore) Aggregate(ctx context.Context, filter *pb.Filter, facets []*pb.Facet) (uint64, []*pb.FacetResult, error) {
+	counter, err := newFacetCounter(facets)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	err = store.Search(ctx, filter, func(laptop *pb.Laptop) error {
+		counter.add(laptop)
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return counter.total, counter.results(), nil
+}
+
+// Update implement the LaptopStore interface
+func (store *SQLLaptopStore) Update(laptop *pb.Laptop, expectedRevision uint64) error {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	revision, err := selectRevision(tx, laptop.Id)
+	if err != nil {
+		return err
+	}
+	if expectedRevision != 0 && revision != expectedRevision {
+		return ErrRevisionMismatch
+	}
+
+	laptop.Revision = revision + 1
+	data, err := proto.Marshal(laptop)
+	if err != nil {
+		return fmt.Errorf("cannot marshal laptop: %w", err)
+	}
+
+	result, err := tx.Exec(
+		`UPDATE laptops
+		SET revision = ?, price_usd = ?, cpu_num_cores = ?, cpu_min_ghz = ?, ram_bits = ?, data = ?
+		WHERE id = ? AND revision = ?`,
+		laptop.Revision,
+		laptop.GetPriceUsd(),
+		laptop.GetCpu().GetNumCores(),
+		laptop.GetCpu().GetMinGhz(),
+		int64(toBit(laptop.GetRam())),
+		data,
+		laptop.Id,
+		revision,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot update laptop: %w", err)
+	}
+	err = requireAffected(result)
+	if err != nil {
+		return err
+	}
+
+	err = store.commit(tx, func() {
+		store.feed.publish(pb.WatchLaptopsResponse_UPDATED, proto.Clone(laptop).(*pb.Laptop))
+	})
+	if err != nil {
+		return fmt.Errorf("cannot commit laptop: %w", err)
+	}
+	return nil
+}
+
+// Delete implement the LaptopStore interface
+func (store *SQLLaptopStore) Delete(id string, expectedRevision uint64) error {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var data []byte
+	err = tx.QueryRow("SELECT data FROM laptops WHERE id = ?", id).Scan(&data)
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("cannot select laptop: %w", err)
+	}
+	laptop, err := unmarshalLaptop(data)
+	if err != nil {
+		return err
+	}
+	if expectedRevision != 0 && laptop.Revision != expectedRevision {
+		return ErrRevisionMismatch
+	}
+
+	result, err := tx.Exec("DELETE FROM laptops WHERE id = ? AND revision = ?", id, laptop.Revision)
+	if err != nil {
+		return fmt.Errorf("cannot delete laptop: %w", err)
+	}
+	err = requireAffected(result)
+	if err != nil {
+		return err
+	}
+
+	err = store.commit(tx, func() {
+		store.feed.publish(pb.WatchLaptopsResponse_DELETED, laptop)
+	})
+	if err != nil {
+		return fmt.Errorf("cannot commit laptop deletion: %w", err)
+	}
+	return nil
+}
+
+// commit commits tx and publishes its changes. The commits and the publications are
+// serialized, so the watchers receive the changes in the order they are in the database
+func (store *SQLLaptopStore) commit(tx *sql.Tx, publish func()) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	err := tx.Commit()
+	if err != nil {
+		return err
+	}
+	publish()
+	return nil
+}
+
+// Watch implement the LaptopStore interface
+func (store *SQLLaptopStore) Watch(resumeToken string) (*LaptopWatcher, error) {
+	return store.feed.Watch(resumeToken)
+}
+
+// selectRevision returns the stored revision of a laptop or ErrNotFound
+func selectRevision(tx *sql.Tx, id string) (uint64, error) {
+	var revision uint64
+	err := tx.QueryRow("SELECT revision FROM laptops WHERE id = ?", id).Scan(&revision)
+	if err == sql.ErrNoRows {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("cannot select laptop revision: %w", err)
+	}
+	return revision, nil
+}
+
+// requireAffected reports a concurrent change when a guarded statement changed no row
+func requireAffected(result sql.Result) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("cannot read affected rows: %w", err)
+	}
+	if n == 0 {
+		return ErrRevisionMismatch
+	}
+	return nil
+}
+
+// filterConditions returns the SQL conditions of the indexed filter fields
+func filterConditions(filter *pb.Filter) ([]string, []interface{}) {
+	var where []string
+	var args []interface{}
+
+	if filter.GetMaxPriceUsd() > 0 {
+		where = append(where, "price_usd <= ?")
+		args = append(args, filter.GetMaxPriceUsd())
+	}
+	if filter.GetMinPriceUsd() > 0 {
+		where = append(where, "price_usd >= ?")
+		args = append(args, filter.GetMinPriceUsd())
+	}
+	if filter.GetMinCpuCores() > 0 {
+		where = append(where, "cpu_num_cores >= ?")
+		args = append(args, filter.GetMinCpuCores())
+	}
+	if filter.GetMinCpuGhz() > 0 {
+		where = append(where, "cpu_min_ghz >= ?")
+		args = append(args, filter.GetMinCpuGhz())
+	}
+	if ram := toBit(filter.GetMinRam()); ram > 0 {
+		where = append(where, "ram_bits >= ?")
+		args = append(args, int64(ram))
+	}
+	return where, args
+}
+
+func unmarshalLaptop(data []byte) (*pb.Laptop, error) {
+	laptop := &pb.Laptop{}
+	err := proto.Unmarshal(data, laptop)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal laptop: %w", err)
+	}
+	return laptop, nil
+}
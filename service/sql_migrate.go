@@ -0,0 +1,116 @@
+package service
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migration is a versioned schema change, its file is named <version>_<description>.sql
+type migration struct {
+	version    int
+	name       string
+	statements []string
+}
+
+// MigrateDatabase applies the embedded migrations newer than the schema version of db
+func MigrateDatabase(db *sql.DB) error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER      PRIMARY KEY,
+		name    VARCHAR(255) NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("cannot create schema_migrations table: %w", err)
+	}
+
+	var current int
+	err = db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&current)
+	if err != nil {
+		return fmt.Errorf("cannot read schema version: %w", err)
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+
+		err = applyMigration(db, m)
+		if err != nil {
+			return err
+		}
+		log.Printf("applied database migration %s", m.name)
+	}
+	return nil
+}
+
+// applyMigration runs the statements of one migration in a transaction
+func applyMigration(db *sql.DB, m *migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("cannot begin migration %s: %w", m.name, err)
+	}
+	defer tx.Rollback()
+
+	for _, statement := range m.statements {
+		_, err = tx.Exec(statement)
+		if err != nil {
+			return fmt.Errorf("cannot apply migration %s: %w", m.name, err)
+		}
+	}
+
+	_, err = tx.Exec("INSERT INTO schema_migrations (version, name) VALUES (?, ?)", m.version, m.name)
+	if err != nil {
+		return fmt.Errorf("cannot record migration %s: %w", m.name, err)
+	}
+
+	return tx.Commit()
+}
+
+// loadMigrations returns the embedded migrations sorted by version
+func loadMigrations() ([]*migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("cannot read migrations: %w", err)
+	}
+
+	migrations := make([]*migration, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		version, err := strconv.Atoi(strings.SplitN(name, "_", 2)[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration file name %s: %w", name, err)
+		}
+
+		data, err := fs.ReadFile(migrationFiles, "migrations/"+name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read migration %s: %w", name, err)
+		}
+
+		m := &migration{version: version, name: name}
+		for _, statement := range strings.Split(string(data), ";") {
+			statement = strings.TrimSpace(statement)
+			if statement != "" {
+				m.statements = append(m.statements, statement)
+			}
+		}
+		migrations = append(migrations, m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+	return migrations, nil
+}
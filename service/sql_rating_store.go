@@ -0,0 +1,223 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// SQLRatingStore stores laptop ratings in a relational database
+type SQLRatingStore struct {
+	db *sql.DB
+}
+
+// NewSQLRatingStore returns a rating store of a migrated database
+func NewSQLRatingStore(db *sql.DB) *SQLRatingStore {
+	return &SQLRatingStore{
+		db: db,
+	}
+}
+
+// Rate implement the RatingStore interface
+func (store *SQLRatingStore) Rate(laptopID string, username string, score float64) (*Rating, error) {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rating, err := selectRating(tx, laptopID)
+	if err != nil {
+		return nil, err
+	}
+
+	// a new score of the user replaces the previous one
+	var previous float64
+	err = tx.QueryRow("SELECT score FROM rating_scores WHERE laptop_id = ? AND username = ?", laptopID, username).Scan(&previous)
+	switch {
+	case err == sql.ErrNoRows:
+		rating.Count++
+		rating.Sum += score
+		_, err = tx.Exec("INSERT INTO rating_scores (laptop_id, username, score) VALUES (?, ?, ?)", laptopID, username, score)
+	case err == nil:
+		rating.Sum += score - previous
+		_, err = tx.Exec("UPDATE rating_scores SET score = ? WHERE laptop_id = ? AND username = ?", score, laptopID, username)
+	default:
+		return nil, fmt.Errorf("cannot select score: %w", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot save score: %w", err)
+	}
+
+	err = saveRating(tx, laptopID, rating)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("cannot commit rating: %w", err)
+	}
+	return rating, nil
+}
+
+// Delete implement the RatingStore interface
+func (store *SQLRatingStore) Delete(laptopID string, username string) (*Rating, error) {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var previous float64
+	err = tx.QueryRow("SELECT score FROM rating_scores WHERE laptop_id = ? AND username = ?", laptopID, username).Scan(&previous)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot select score: %w", err)
+	}
+	_, err = tx.Exec("DELETE FROM rating_scores WHERE laptop_id = ? AND username = ?", laptopID, username)
+	if err != nil {
+		return nil, fmt.Errorf("cannot delete score: %w", err)
+	}
+
+	rating, err := selectRating(tx, laptopID)
+	if err != nil {
+		return nil, err
+	}
+	rating.Count--
+	rating.Sum -= previous
+	err = saveRating(tx, laptopID, rating)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("cannot commit rating: %w", err)
+	}
+	return rating, nil
+}
+
+// selectRating returns the rating of a laptop, a zero rating if it isn't rated
+func selectRating(tx *sql.Tx, laptopID string) (*Rating, error) {
+	rating := &Rating{}
+	err := tx.QueryRow("SELECT count, sum FROM ratings WHERE laptop_id = ?", laptopID).Scan(&rating.Count, &rating.Sum)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("cannot select rating: %w", err)
+	}
+	return rating, nil
+}
+
+// saveRating replaces the rating of a laptop, a zero rating is removed
+func saveRating(tx *sql.Tx, laptopID string, rating *Rating) error {
+	_, err := tx.Exec("DELETE FROM ratings WHERE laptop_id = ?", laptopID)
+	if err == nil && rating.Count > 0 {
+		_, err = tx.Exec("INSERT INTO ratings (laptop_id, count, sum) VALUES (?, ?, ?)", laptopID, rating.Count, rating.Sum)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot save rating: %w", err)
+	}
+	return nil
+}
+
+// Find implement the RatingStore interface
+func (store *SQLRatingStore) Find(laptopID string) (*Rating, error) {
+	rating := &Rating{}
+	err := store.db.QueryRow("SELECT count, sum FROM ratings WHERE laptop_id = ?", laptopID).Scan(&rating.Count, &rating.Sum)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot select rating: %w", err)
+	}
+	return rating, nil
+}
+
+// Stats implement the RatingStore interface
+func (store *SQLRatingStore) Stats(laptopID string) (*RatingStats, error) {
+	stats, err := store.BatchStats([]string{laptopID})
+	if err != nil {
+		return nil, err
+	}
+	return stats[laptopID], nil
+}
+
+// BatchStats implement the RatingStore interface. The scores rated before the
+// rating_scores migration have no user, they are only in the count and the sum of the ratings
+// and are reported as the legacy scores
+func (store *SQLRatingStore) BatchStats(laptopIDs []string) (map[string]*RatingStats, error) {
+	stats := make(map[string]*RatingStats, len(laptopIDs))
+	if len(laptopIDs) == 0 {
+		return stats, nil
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(laptopIDs)), ", ")
+	args := make([]interface{}, len(laptopIDs))
+	for i, laptopID := range laptopIDs {
+		args[i] = laptopID
+	}
+
+	// the scores and the ratings are read in one transaction to be consistent
+	tx, err := store.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query("SELECT laptop_id, score FROM rating_scores WHERE laptop_id IN ("+placeholders+") ORDER BY laptop_id, score", args...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot select scores: %w", err)
+	}
+	defer rows.Close()
+	scores := make(map[string][]float64)
+	for rows.Next() {
+		var laptopID string
+		var score float64
+		err = rows.Scan(&laptopID, &score)
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan score: %w", err)
+		}
+		scores[laptopID] = append(scores[laptopID], score)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("cannot select scores: %w", err)
+	}
+	for laptopID, laptopScores := range scores {
+		stats[laptopID] = newRatingStats(laptopScores)
+	}
+
+	rows, err = tx.Query("SELECT laptop_id, count, sum FROM ratings WHERE laptop_id IN ("+placeholders+")", args...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot select ratings: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var laptopID string
+		rating := &Rating{}
+		err = rows.Scan(&laptopID, &rating.Count, &rating.Sum)
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan rating: %w", err)
+		}
+		// the rating counts every score, the legacy scores are the ones without a user
+		laptopScores := scores[laptopID]
+		if int(rating.Count) <= len(laptopScores) {
+			continue
+		}
+		legacy := &Rating{Count: rating.Count - uint32(len(laptopScores)), Sum: rating.Sum}
+		for _, score := range laptopScores {
+			legacy.Sum -= score
+		}
+
+		laptopStats := stats[laptopID]
+		if laptopStats == nil {
+			laptopStats = &RatingStats{}
+			stats[laptopID] = laptopStats
+		}
+		laptopStats.LegacyCount = legacy.Count
+		laptopStats.LegacyAverage = legacy.Average()
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("cannot select ratings: %w", err)
+	}
+	return stats, nil
+}
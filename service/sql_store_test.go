@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"library/v1/pb"
+	"library/v1/sample"
+	_ "modernc.org/sqlite"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// newTestDB returns a migrated SQLite database in a temporary directory
+func newTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	require.NoError(t, MigrateDatabase(db))
+	// Migrating again is a no-op
+	require.NoError(t, MigrateDatabase(db))
+	return db
+}
+
+// TestSQLLaptopStore test the laptop store of a SQL database
+func TestSQLLaptopStore(t *testing.T) {
+	t.Parallel()
+
+	store := NewSQLLaptopStore(newTestDB(t))
+
+	laptop := sample.NewLaptop()
+	require.NoError(t, store.Save(laptop))
+	require.EqualValues(t, 1, laptop.Revision)
+	require.ErrorIs(t, store.Save(laptop), ErrAlreadyExists)
+
+	other, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	requireSameLaptop(t, laptop, other)
+
+	other, err = store.Find(sample.NewLaptop().Id)
+	require.NoError(t, err)
+	require.Nil(t, other)
+
+	laptop.PriceUsd = 1500
+	require.NoError(t, store.Update(laptop, 1))
+	require.EqualValues(t, 2, laptop.Revision)
+	require.ErrorIs(t, store.Update(laptop, 1), ErrRevisionMismatch)
+	require.ErrorIs(t, store.Update(sample.NewLaptop(), 0), ErrNotFound)
+
+	other, err = store.Find(laptop.Id)
+	require.NoError(t, err)
+	requireSameLaptop(t, laptop, other)
+
+	require.ErrorIs(t, store.Delete(laptop.Id, 1), ErrRevisionMismatch)
+	require.NoError(t, store.Delete(laptop.Id, 2))
+	require.ErrorIs(t, store.Delete(laptop.Id, 0), ErrNotFound)
+
+	existing := sample.NewLaptop()
+	require.NoError(t, store.Save(existing))
+	batch := []*pb.Laptop{sample.NewLaptop(), existing}
+	err = store.SaveAll(batch)
+	var saveErr *SaveError
+	require.ErrorAs(t, err, &saveErr)
+	require.Equal(t, 1, saveErr.Index)
+	require.ErrorIs(t, err, ErrAlreadyExists)
+
+	// The batch is rolled back
+	other, err = store.Find(batch[0].Id)
+	require.NoError(t, err)
+	require.Nil(t, other)
+
+	batch = []*pb.Laptop{sample.NewLaptop(), sample.NewLaptop()}
+	require.NoError(t, store.SaveAll(batch))
+	for _, laptop := range batch {
+		other, err = store.Find(laptop.Id)
+		require.NoError(t, err)
+		requireSameLaptop(t, laptop, other)
+	}
+}
+
+// TestSQLLaptopStoreSearch test filters are honored by the SQL laptop store
+func TestSQLLaptopStoreSearch(t *testing.T) {
+	t.Parallel()
+
+	store := NewSQLLaptopStore(newTestDB(t))
+	filter := &pb.Filter{
+		MaxPriceUsd: 3000,
+		MinCpuCores: 4,
+		MinCpuGhz:   3.0,
+		MinRam:      &pb.Memory{Unit: pb.Memory_GIGABYTE, Value: 4},
+	}
+
+	expectedIDs := make(map[string]bool)
+	for i := 0; i < 5; i++ {
+		laptop := sample.NewLaptop()
+		laptop.PriceUsd = 2000
+		laptop.Cpu.NumCores = 4
+		laptop.Cpu.MinGhz = 3.5
+		laptop.Ram = &pb.Memory{Unit: pb.Memory_GIGABYTE, Value: 8}
+		switch i {
+		case 0:
+			laptop.PriceUsd = 3002
+		case 1:
+			laptop.Cpu.NumCores = 2
+		case 2:
+			laptop.Cpu.MinGhz = 2.0
+		case 3:
+			laptop.Ram = &pb.Memory{Unit: pb.Memory_MEGABYTE, Value: 2048}
+		default:
+			expectedIDs[laptop.Id] = true
+		}
+		require.NoError(t, store.Save(laptop))
+	}
+
+	found := 0
+	err := store.Search(context.Background(), filter, func(laptop *pb.Laptop) error {
+		require.Contains(t, expectedIDs, laptop.Id)
+		found++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, len(expectedIDs), found)
+
+	total, results, err := store.Aggregate(context.Background(), nil, []*pb.Facet{{Field: "ram"}})
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), total)
+	require.Len(t, results, 1)
+	require.Equal(t, "8GB", results[0].GetBuckets()[0].GetKey())
+	require.Equal(t, uint64(4), results[0].GetBuckets()[0].GetCount())
+}
+
+// TestSQLLaptopStoreWatchOrder test the watchers receive concurrent updates in the order of their commits
+func TestSQLLaptopStoreWatchOrder(t *testing.T) {
+	t.Parallel()
+
+	store := NewSQLLaptopStore(newTestDB(t))
+	laptop := sample.NewLaptop()
+	require.NoError(t, store.Save(laptop))
+	watcher, err := store.Watch("")
+	require.NoError(t, err)
+	defer watcher.Close()
+
+	const updates = 50
+	var wg sync.WaitGroup
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+		go func(price float64) {
+			defer wg.Done()
+			other := proto.Clone(laptop).(*pb.Laptop)
+			other.PriceUsd = price
+			require.NoError(t, store.Update(other, 0))
+		}(float64(1000 + i))
+	}
+	wg.Wait()
+
+	// the last received laptop is the stored one
+	last := laptop
+	for i := 0; i < updates; i++ {
+		event := <-watcher.Events()
+		require.Equal(t, pb.WatchLaptopsResponse_UPDATED, event.Type)
+		require.Greater(t, event.Laptop.GetRevision(), last.GetRevision())
+		last = event.Laptop
+	}
+	stored, err := store.Find(laptop.GetId())
+	require.NoError(t, err)
+	require.Equal(t, stored.GetRevision(), last.GetRevision())
+	require.Equal(t, stored.GetPriceUsd(), last.GetPriceUsd())
+}
+
+// TestSQLLaptopStoreConcurrentSave test a laptop saved by concurrent transactions is created once
+func TestSQLLaptopStoreConcurrentSave(t *testing.T) {
+	t.Parallel()
+
+	// the transactions run on their own connections, the writers wait for the lock of the database
+	db, err := sql.Open("sqlite", "file:"+filepath.Join(t.TempDir(), "test.db")+"?_pragma=busy_timeout(10000)")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, MigrateDatabase(db))
+	store := NewSQLLaptopStore(db)
+
+	const saves = 8
+	laptop := sample.NewLaptop()
+	errs := make(chan error, saves)
+	for i := 0; i < saves; i++ {
+		go func() {
+			errs <- store.Save(proto.Clone(laptop).(*pb.Laptop))
+		}()
+	}
+
+	created := 0
+	for i := 0; i < saves; i++ {
+		err := <-errs
+		if err == nil {
+			created++
+			continue
+		}
+		require.ErrorIs(t, err, ErrAlreadyExists)
+	}
+	require.Equal(t, 1, created)
+}
+
+// TestSQLRatingAndUserStore test the rating and user stores of a SQL database
+func TestSQLRatingAndUserStore(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+
+	requireRatingStore(t, NewSQLRatingStore(db))
+
+	// the scores rated before the rating_scores migration are reported apart from the scores of the users
+	ratingStore := NewSQLRatingStore(db)
+	_, err := db.Exec("INSERT INTO ratings (laptop_id, count, sum) VALUES ('legacy', 2, 12), ('legacy_only', 1, 4)")
+	require.NoError(t, err)
+	_, err = ratingStore.Rate("legacy", "user1", 9)
+	require.NoError(t, err)
+	_, err = ratingStore.Rate("legacy", "user2", 7)
+	require.NoError(t, err)
+	stats, err := ratingStore.BatchStats([]string{"legacy", "legacy_only"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]*RatingStats{
+		"legacy": {
+			Count:         2,
+			Average:       8,
+			Min:           7,
+			Max:           9,
+			StdDev:        1,
+			Histogram:     [HistogramSize]uint32{6: 1, 8: 1},
+			LegacyCount:   2,
+			LegacyAverage: 6,
+		},
+		"legacy_only": {
+			LegacyCount:   1,
+			LegacyAverage: 4,
+		},
+	}, stats)
+	require.Equal(t, 7.0, stats["legacy"].TotalAverage())
+	rating, err := ratingStore.Find("legacy")
+	require.NoError(t, err)
+	require.EqualValues(t, 4, rating.Count)
+	require.Equal(t, 7.0, rating.Average())
+
+	userStore := NewSQLUserStore(db)
+	user, err := NewUser("user1", "secret", "admin")
+	require.NoError(t, err)
+	require.NoError(t, userStore.Save(user))
+	require.ErrorIs(t, userStore.Save(user), ErrAlreadyExists)
+
+	other, err := userStore.Find("user1")
+	require.NoError(t, err)
+	require.Equal(t, user, other)
+	require.True(t, other.IsCorrectPassword("secret"))
+
+	other, err = userStore.Find("user2")
+	require.NoError(t, err)
+	require.Nil(t, other)
+}
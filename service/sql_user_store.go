@@ -0,0 +1,64 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// SQLUserStore stores users in a relational database
+type SQLUserStore struct {
+	db *sql.DB
+}
+
+// NewSQLUserStore returns a user store of a migrated database
+func NewSQLUserStore(db *sql.DB) *SQLUserStore {
+	return &SQLUserStore{
+		db: db,
+	}
+}
+
+// Save implement the UserStore interface
+func (store *SQLUserStore) Save(user *User) error {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var username string
+	err = tx.QueryRow("SELECT username FROM users WHERE username = ?", user.UserName).Scan(&username)
+	if err == nil {
+		return ErrAlreadyExists
+	}
+	if err != sql.ErrNoRows {
+		return fmt.Errorf("cannot select user: %w", err)
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO users (username, hash_password, role) VALUES (?, ?, ?)",
+		user.UserName,
+		user.HashPassword,
+		user.Role,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot insert user: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// Find implement the UserStore interface
+func (store *SQLUserStore) Find(username string) (*User, error) {
+	user := &User{}
+	err := store.db.QueryRow(
+		"SELECT username, hash_password, role FROM users WHERE username = ?",
+		username,
+	).Scan(&user.UserName, &user.HashPassword, &user.Role)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot select user: %w", err)
+	}
+	return user, nil
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"library/v1/pb"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// bm25K1 and bm25B are the term frequency saturation and length normalization of BM25
+	bm25K1 = 1.2
+	bm25B  = 0.75
+	// prefixWeight scales the score of a term that only starts with a query token
+	prefixWeight = 0.5
+)
+
+// textIndex is an inverted index of the text of laptops: brand, name, cpu.name and gpus[].name
+type textIndex struct {
+	postings    map[string]map[string]int // term => laptop ID => term frequency
+	lengths     map[string]int            // laptop ID => number of terms
+	totalLength int
+	terms       []string // sorted terms of postings, for prefix matching
+}
+
+func newTextIndex() *textIndex {
+	return &textIndex{
+		postings: make(map[string]map[string]int),
+		lengths:  make(map[string]int),
+	}
+}
+
+// tokenize splits text into lower case words of letters and digits
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// laptopText returns the indexed words of a laptop
+func laptopText(laptop *pb.Laptop) []string {
+	words := tokenize(laptop.GetBrand())
+	words = append(words, tokenize(laptop.GetName())...)
+	words = append(words, tokenize(laptop.GetCpu().GetName())...)
+	for _, gpu := range laptop.GetGpus() {
+		words = append(words, tokenize(gpu.GetName())...)
+	}
+	return words
+}
+
+// insert adds the text of the laptop to the index
+func (index *textIndex) insert(laptop *pb.Laptop) {
+	words := laptopText(laptop)
+	id := laptop.GetId()
+	for _, word := range words {
+		docs := index.postings[word]
+		if docs == nil {
+			docs = make(map[string]int)
+			index.postings[word] = docs
+
+			i := sort.SearchStrings(index.terms, word)
+			index.terms = append(index.terms, "")
+			copy(index.terms[i+1:], index.terms[i:])
+			index.terms[i] = word
+		}
+		docs[id]++
+	}
+	index.lengths[id] = len(words)
+	index.totalLength += len(words)
+}
+
+// remove deletes the text of the laptop from the index, laptop must be the indexed version
+func (index *textIndex) remove(laptop *pb.Laptop) {
+	id := laptop.GetId()
+	length, ok := index.lengths[id]
+	if !ok {
+		return
+	}
+
+	for _, word := range laptopText(laptop) {
+		docs := index.postings[word]
+		delete(docs, id)
+		if len(docs) == 0 && docs != nil {
+			delete(index.postings, word)
+			i := sort.SearchStrings(index.terms, word)
+			index.terms = append(index.terms[:i], index.terms[i+1:]...)
+		}
+	}
+	delete(index.lengths, id)
+	index.totalLength -= length
+}
+
+// search returns the BM25 score of the laptops that match every word of text.
+// A word matches the terms it is a prefix of, the terms longer than the word score less.
+func (index *textIndex) search(text string) map[string]float64 {
+	words := tokenize(text)
+	if len(words) == 0 || len(index.lengths) == 0 {
+		return nil
+	}
+
+	var scores map[string]float64
+	for _, word := range words {
+		wordScores := make(map[string]float64)
+		for _, term := range index.prefixTerms(word) {
+			weight := 1.0
+			if term != word {
+				weight = prefixWeight
+			}
+			for id, score := range index.termScores(term) {
+				wordScores[id] += weight * score
+			}
+		}
+
+		// a laptop must match every word
+		if scores == nil {
+			scores = wordScores
+			continue
+		}
+		for id := range scores {
+			if _, ok := wordScores[id]; !ok {
+				delete(scores, id)
+				continue
+			}
+			scores[id] += wordScores[id]
+		}
+	}
+	return scores
+}
+
+// prefixTerms returns the indexed terms that start with prefix
+func (index *textIndex) prefixTerms(prefix string) []string {
+	from := sort.SearchStrings(index.terms, prefix)
+	to := from
+	for to < len(index.terms) && strings.HasPrefix(index.terms[to], prefix) {
+		to++
+	}
+	return index.terms[from:to]
+}
+
+// termScores returns the BM25 score of term for each laptop that contains it
+func (index *textIndex) termScores(term string) map[string]float64 {
+	docs := index.postings[term]
+	n := float64(len(index.lengths))
+	df := float64(len(docs))
+	idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+	averageLength := float64(index.totalLength) / n
+
+	scores := make(map[string]float64, len(docs))
+	for id, tf := range docs {
+		frequency := float64(tf)
+		norm := bm25K1 * (1 - bm25B + bm25B*float64(index.lengths[id])/averageLength)
+		scores[id] = idf * frequency * (bm25K1 + 1) / (frequency + norm)
+	}
+	return scores
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"github.com/stretchr/testify/require"
+	"library/v1/pb"
+	"testing"
+)
+
+func newTestTextIndex() (*textIndex, []*pb.Laptop) {
+	index := newTextIndex()
+	laptops := []*pb.Laptop{
+		{
+			Id:    "thinkpad",
+			Brand: "Lenovo",
+			Name:  "ThinkPad X1",
+			Cpu:   &pb.CPU{Name: "Core i7-1165G7"},
+			Gpus:  []*pb.GPU{{Name: "RTX 3050"}},
+		},
+		{
+			Id:    "thinkbook",
+			Brand: "Lenovo",
+			Name:  "ThinkBook 14",
+			Cpu:   &pb.CPU{Name: "Core i5-1135G7"},
+			Gpus:  []*pb.GPU{{Name: "Iris Xe"}},
+		},
+		{
+			Id:    "xps",
+			Brand: "Dell",
+			Name:  "XPS 15",
+			Cpu:   &pb.CPU{Name: "Core i7-11800H"},
+			Gpus:  []*pb.GPU{{Name: "RTX 3050 Ti"}},
+		},
+	}
+	for _, laptop := range laptops {
+		index.insert(laptop)
+	}
+	return index, laptops
+}
+
+func TestTextIndexSearch(t *testing.T) {
+	t.Parallel()
+
+	index, _ := newTestTextIndex()
+
+	type testCase struct {
+		text string
+		ids  []string
+	}
+
+	testCases := []testCase{
+		{"thinkpad i7 rtx", []string{"thinkpad"}},
+		{"THINK", []string{"thinkpad", "thinkbook"}},
+		{"i7 rtx", []string{"thinkpad", "xps"}},
+		{"lenovo dell", nil},
+		{"  ", nil},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.text, func(t *testing.T) {
+			t.Parallel()
+
+			scores := index.search(tc.text)
+			require.Len(t, scores, len(tc.ids))
+			for _, id := range tc.ids {
+				require.Greater(t, scores[id], 0.0)
+			}
+		})
+	}
+
+}
+
+func TestTextIndexUpdate(t *testing.T) {
+	t.Parallel()
+
+	index, laptops := newTestTextIndex()
+
+	// an exact word scores more than a word it is a prefix of
+	index.insert(&pb.Laptop{Id: "xpsx", Name: "XPSX"})
+	scores := index.search("xps")
+	require.Greater(t, scores["xps"], scores["xpsx"])
+
+	index.remove(laptops[0])
+	require.Empty(t, index.search("thinkpad"))
+	require.Len(t, index.search("think"), 1)
+}
@@ -0,0 +1,413 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"hash"
+	"hash/fnv"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UploadExpiration is how long an upload without new chunks is kept
+const UploadExpiration = 24 * time.Hour
+
+// ErrUploadOffset is returned when a chunk starts after the committed offset of its upload
+var ErrUploadOffset = errors.New("chunk offset is after the committed offset")
+
+// UploadStore keeps the data of the resumable uploads until they are committed
+type UploadStore interface {
+	// Create starts an upload of a laptop image of the given size, 0 if it is unknown
+	Create(laptopID string, imageType string, size int64) (*UploadInfo, error)
+	// Find returns the upload with the given ID, or nil if it doesn't exist
+	Find(uploadID string) (*UploadInfo, error)
+	// Append writes a chunk at offset, which must not be after the committed offset,
+	// the bytes of the chunk before the committed offset are skipped. It returns the upload after the chunk
+	Append(uploadID string, offset int64, data []byte) (*UploadInfo, error)
+	// Open returns the data of the upload
+	Open(uploadID string) (io.ReadCloser, error)
+	// Delete removes the upload and its data
+	Delete(uploadID string) error
+}
+
+// UploadInfo contains information of a resumable upload
+type UploadInfo struct {
+	ID        string
+	LaptopID  string
+	ImageType string
+	// Size is the declared size of the image, 0 if it is unknown
+	Size int64
+	// Offset is the number of bytes received
+	Offset int64
+	// SHA256 is the checksum of the bytes received
+	SHA256    []byte
+	UpdatedAt time.Time
+}
+
+// uploadPrefix starts the keys of the uploads of a BlobUploadStore
+const uploadPrefix = "uploads/"
+
+// uploadLockCount is the number of locks of the uploads of a BlobUploadStore
+const uploadLockCount = 64
+
+// BlobUploadStore keeps the uploads in a BlobStore, so that an upload started on a server
+// can be resumed on another one sharing the blob store. Each chunk is stored in a blob
+// named by its offset, which starts with the state of the upload after the chunk:
+// a chunk is stored with one write, and the info of the upload is only written when it is created
+type BlobUploadStore struct {
+	blobs BlobStore
+	// locks order the chunks of the uploads sent to this server, an upload is locked by the lock of its ID
+	locks [uploadLockCount]sync.Mutex
+}
+
+// blobUpload is the info of an upload stored in a BlobUploadStore
+type blobUpload struct {
+	Info UploadInfo `json:"info"`
+	// Hash is the state of the SHA-256 of the bytes received
+	Hash []byte `json:"hash"`
+}
+
+// uploadChunkHeader is the first line of the blob of a chunk, the state of the upload after the chunk
+type uploadChunkHeader struct {
+	Offset    int64     `json:"offset"`
+	Hash      []byte    `json:"hash"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewBlobUploadStore returns a new BlobUploadStore
+func NewBlobUploadStore(blobs BlobStore) *BlobUploadStore {
+	return &BlobUploadStore{
+		blobs: blobs,
+	}
+}
+
+// uploadKey returns the key of the info of an upload
+func uploadKey(uploadID string) string {
+	return uploadPrefix + uploadID + ".json"
+}
+
+// uploadChunkPrefix starts the keys of the chunks of an upload
+func uploadChunkPrefix(uploadID string) string {
+	return uploadPrefix + uploadID + "/"
+}
+
+// uploadChunkKey returns the key of the chunk of an upload at offset, the keys are sorted by offset
+func uploadChunkKey(uploadID string, offset int64) string {
+	return fmt.Sprintf("%s%020d", uploadChunkPrefix(uploadID), offset)
+}
+
+func (store *BlobUploadStore) lock(uploadID string) *sync.Mutex {
+	hash := fnv.New32a()
+	hash.Write([]byte(uploadID))
+	return &store.locks[hash.Sum32()%uploadLockCount]
+}
+
+// read returns the info of an upload, updated by the header of its last chunk
+func (store *BlobUploadStore) read(uploadID string) (*blobUpload, error) {
+	reader, err := store.blobs.Get(uploadKey(uploadID))
+	if err != nil {
+		return nil, fmt.Errorf("cannot open upload info: %w", err)
+	}
+	defer reader.Close()
+
+	upload := &blobUpload{}
+	err = json.NewDecoder(reader).Decode(upload)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read upload info: %w", err)
+	}
+
+	keys, err := store.blobs.List(uploadChunkPrefix(uploadID))
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) > 0 {
+		header, chunk, err := openUploadChunk(store.blobs, keys[len(keys)-1])
+		if err != nil {
+			return nil, err
+		}
+		chunk.Close()
+
+		hash, err := decodeUploadHash(header.Hash)
+		if err != nil {
+			return nil, err
+		}
+		upload.Hash = header.Hash
+		upload.Info.Offset = header.Offset
+		upload.Info.SHA256 = hash.Sum(nil)
+		upload.Info.UpdatedAt = header.UpdatedAt
+	}
+
+	if time.Since(upload.Info.UpdatedAt) > UploadExpiration {
+		return nil, ErrNotFound
+	}
+	return upload, nil
+}
+
+func (store *BlobUploadStore) write(upload *blobUpload) error {
+	data, err := json.Marshal(upload)
+	if err != nil {
+		return fmt.Errorf("cannot encode upload info: %w", err)
+	}
+	err = store.blobs.Put(uploadKey(upload.Info.ID), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("cannot store upload info: %w", err)
+	}
+	return nil
+}
+
+// writeChunk stores a chunk at offset with the state of the upload after it
+func (store *BlobUploadStore) writeChunk(uploadID string, offset int64, header *uploadChunkHeader, data []byte) error {
+	line, err := json.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("cannot encode upload chunk header: %w", err)
+	}
+	line = append(line, '\n')
+	err = store.blobs.Put(uploadChunkKey(uploadID, offset), io.MultiReader(bytes.NewReader(line), bytes.NewReader(data)))
+	if err != nil {
+		return fmt.Errorf("cannot store upload chunk: %w", err)
+	}
+	return nil
+}
+
+// openUploadChunk returns the header of a chunk and the reader of its data
+func openUploadChunk(blobs BlobStore, key string) (*uploadChunkHeader, io.ReadCloser, error) {
+	blob, err := blobs.Get(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot open upload chunk: %w", err)
+	}
+
+	reader := bufio.NewReader(blob)
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		blob.Close()
+		return nil, nil, fmt.Errorf("cannot read upload chunk header: %w", err)
+	}
+	header := &uploadChunkHeader{}
+	err = json.Unmarshal(line, header)
+	if err != nil {
+		blob.Close()
+		return nil, nil, fmt.Errorf("cannot read upload chunk header: %w", err)
+	}
+	return header, struct {
+		io.Reader
+		io.Closer
+	}{reader, blob}, nil
+}
+
+// decodeUploadHash returns the SHA-256 of the bytes received from its state
+func decodeUploadHash(state []byte) (hash.Hash, error) {
+	hash := sha256.New()
+	err := hash.(encoding.BinaryUnmarshaler).UnmarshalBinary(state)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode upload checksum: %w", err)
+	}
+	return hash, nil
+}
+
+func (store *BlobUploadStore) Create(laptopID string, imageType string, size int64) (*UploadInfo, error) {
+	uploadID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate upload id: %w", err)
+	}
+
+	err = store.removeExpired()
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	state, err := hash.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode upload checksum: %w", err)
+	}
+	upload := &blobUpload{
+		Info: UploadInfo{
+			ID:        uploadID.String(),
+			LaptopID:  laptopID,
+			ImageType: imageType,
+			Size:      size,
+			SHA256:    hash.Sum(nil),
+			UpdatedAt: time.Now(),
+		},
+		Hash: state,
+	}
+	err = store.write(upload)
+	if err != nil {
+		return nil, err
+	}
+	return &upload.Info, nil
+}
+
+// removeExpired removes the uploads abandoned for longer than UploadExpiration
+func (store *BlobUploadStore) removeExpired() error {
+	keys, err := store.blobs.List(uploadPrefix)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		uploadID := strings.TrimSuffix(path.Base(key), ".json")
+		if key != uploadKey(uploadID) {
+			// a chunk
+			continue
+		}
+		_, err = store.read(uploadID)
+		if errors.Is(err, ErrNotFound) {
+			err = store.Delete(uploadID)
+		}
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (store *BlobUploadStore) Find(uploadID string) (*UploadInfo, error) {
+	upload, err := store.read(uploadID)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &upload.Info, nil
+}
+
+func (store *BlobUploadStore) Append(uploadID string, offset int64, data []byte) (*UploadInfo, error) {
+	lock := store.lock(uploadID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	upload, err := store.read(uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	committed := upload.Info.Offset
+	if offset > committed {
+		return nil, fmt.Errorf("%w: %d > %d", ErrUploadOffset, offset, committed)
+	}
+	// the chunk was sent again after a dropped stream
+	skip := committed - offset
+	if skip >= int64(len(data)) {
+		return &upload.Info, nil
+	}
+	data = data[skip:]
+
+	hash, err := decodeUploadHash(upload.Hash)
+	if err != nil {
+		return nil, err
+	}
+	hash.Write(data)
+	state, err := hash.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode upload checksum: %w", err)
+	}
+	header := &uploadChunkHeader{
+		Offset:    committed + int64(len(data)),
+		Hash:      state,
+		UpdatedAt: time.Now(),
+	}
+	err = store.writeChunk(uploadID, committed, header, data)
+	if err != nil {
+		return nil, err
+	}
+
+	upload.Info.Offset = header.Offset
+	upload.Info.SHA256 = hash.Sum(nil)
+	upload.Info.UpdatedAt = header.UpdatedAt
+	return &upload.Info, nil
+}
+
+func (store *BlobUploadStore) Open(uploadID string) (io.ReadCloser, error) {
+	upload, err := store.read(uploadID)
+	if err != nil {
+		return nil, err
+	}
+	chunks, err := store.blobs.List(uploadChunkPrefix(uploadID))
+	if err != nil {
+		return nil, err
+	}
+
+	// a chunk stored since the upload was read is not part of it
+	reader := &chunkReader{blobs: store.blobs, chunks: chunks}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(reader, upload.Info.Offset), reader}, nil
+}
+
+func (store *BlobUploadStore) Delete(uploadID string) error {
+	reader, err := store.blobs.Get(uploadKey(uploadID))
+	if err != nil {
+		return err
+	}
+	reader.Close()
+	keys, err := store.blobs.List(uploadChunkPrefix(uploadID))
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		err = store.blobs.Delete(key)
+		if err != nil {
+			return fmt.Errorf("cannot remove upload chunk: %w", err)
+		}
+	}
+	err = store.blobs.Delete(uploadKey(uploadID))
+	if err != nil {
+		return fmt.Errorf("cannot remove upload info: %w", err)
+	}
+	return nil
+}
+
+// chunkReader reads the chunks of an upload one after the other, each chunk is opened when it is reached
+type chunkReader struct {
+	blobs  BlobStore
+	chunks []string
+	chunk  io.ReadCloser
+}
+
+func (reader *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if reader.chunk == nil {
+			if len(reader.chunks) == 0 {
+				return 0, io.EOF
+			}
+			_, chunk, err := openUploadChunk(reader.blobs, reader.chunks[0])
+			if err != nil {
+				return 0, err
+			}
+			reader.chunk = chunk
+			reader.chunks = reader.chunks[1:]
+		}
+
+		n, err := reader.chunk.Read(p)
+		if err == io.EOF {
+			err = reader.Close()
+			if err != nil {
+				return n, err
+			}
+			if n == 0 {
+				continue
+			}
+		}
+		return n, err
+	}
+}
+
+func (reader *chunkReader) Close() error {
+	if reader.chunk == nil {
+		return nil
+	}
+	err := reader.chunk.Close()
+	reader.chunk = nil
+	return err
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"crypto/sha256"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+// TestBlobUploadStoreAppend checks the chunks sent again and after a gap
+func TestBlobUploadStoreAppend(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("0123456789")
+
+	type chunk struct {
+		offset int64
+		end    int64
+	}
+	testCases := []struct {
+		name   string
+		chunks []chunk
+		offset int64
+		err    error
+	}{
+		{
+			name:   "in_order",
+			chunks: []chunk{{0, 4}, {4, 8}, {8, 10}},
+			offset: 10,
+		},
+		{
+			name:   "sent_again",
+			chunks: []chunk{{0, 4}, {0, 4}, {2, 6}, {6, 10}},
+			offset: 10,
+		},
+		{
+			name:   "gap",
+			chunks: []chunk{{0, 4}, {6, 10}},
+			offset: 4,
+			err:    ErrUploadOffset,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := NewBlobUploadStore(NewLocalBlobStore(t.TempDir()))
+			upload, err := store.Create("laptop", ".png", int64(len(data)))
+			require.NoError(t, err)
+
+			for _, c := range tc.chunks {
+				_, err = store.Append(upload.ID, c.offset, data[c.offset:c.end])
+				if err != nil {
+					break
+				}
+			}
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			upload, err = store.Find(upload.ID)
+			require.NoError(t, err)
+			require.Equal(t, tc.offset, upload.Offset)
+			checksum := sha256.Sum256(data[:tc.offset])
+			require.Equal(t, checksum[:], upload.SHA256)
+
+			reader, err := store.Open(upload.ID)
+			require.NoError(t, err)
+			defer reader.Close()
+			received, err := ioutil.ReadAll(reader)
+			require.NoError(t, err)
+			require.Equal(t, data[:tc.offset], received)
+		})
+	}
+}
+
+// TestBlobUploadStoreResume checks that an upload started with a store is resumed with another one sharing its blobs
+func TestBlobUploadStoreResume(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("0123456789")
+	blobs := NewLocalBlobStore(t.TempDir())
+	first := NewBlobUploadStore(blobs)
+	second := NewBlobUploadStore(blobs)
+
+	upload, err := first.Create("laptop", ".png", int64(len(data)))
+	require.NoError(t, err)
+	_, err = first.Append(upload.ID, 0, data[:4])
+	require.NoError(t, err)
+
+	found, err := second.Find(upload.ID)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, found.Offset)
+	_, err = second.Append(upload.ID, 6, data[6:])
+	require.ErrorIs(t, err, ErrUploadOffset)
+	// a chunk is stored with one write, the info of the upload is not written again
+	info, err := blobs.Get(uploadKey(upload.ID))
+	require.NoError(t, err)
+	created, err := ioutil.ReadAll(info)
+	info.Close()
+	require.NoError(t, err)
+	_, err = second.Append(upload.ID, 2, data[2:7])
+	require.NoError(t, err)
+	info, err = blobs.Get(uploadKey(upload.ID))
+	require.NoError(t, err)
+	stored, err := ioutil.ReadAll(info)
+	info.Close()
+	require.NoError(t, err)
+	require.Equal(t, created, stored)
+
+	found, err = first.Find(upload.ID)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, found.Offset)
+	checksum := sha256.Sum256(data[:7])
+	require.Equal(t, checksum[:], found.SHA256)
+	reader, err := first.Open(upload.ID)
+	require.NoError(t, err)
+	received, err := ioutil.ReadAll(reader)
+	reader.Close()
+	require.NoError(t, err)
+	require.Equal(t, data[:7], received)
+
+	found, err = first.Append(upload.ID, 7, data[7:])
+	require.NoError(t, err)
+	checksum = sha256.Sum256(data)
+	require.Equal(t, checksum[:], found.SHA256)
+	reader, err = second.Open(upload.ID)
+	require.NoError(t, err)
+	received, err = ioutil.ReadAll(reader)
+	reader.Close()
+	require.NoError(t, err)
+	require.Equal(t, data, received)
+
+	err = second.Delete(upload.ID)
+	require.NoError(t, err)
+	err = first.Delete(upload.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+	keys, err := blobs.List("")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
+
+// TestBlobUploadStoreExpiration checks that the abandoned uploads are removed
+func TestBlobUploadStoreExpiration(t *testing.T) {
+	t.Parallel()
+
+	blobs := NewLocalBlobStore(t.TempDir())
+	store := NewBlobUploadStore(blobs)
+	upload, err := store.Create("laptop", ".png", 0)
+	require.NoError(t, err)
+	_, err = store.Append(upload.ID, 0, []byte("data"))
+	require.NoError(t, err)
+
+	abandoned, err := store.read(upload.ID)
+	require.NoError(t, err)
+	header := &uploadChunkHeader{
+		Offset:    abandoned.Info.Offset,
+		Hash:      abandoned.Hash,
+		UpdatedAt: time.Now().Add(-UploadExpiration - time.Minute),
+	}
+	err = store.writeChunk(upload.ID, 0, header, []byte("data"))
+	require.NoError(t, err)
+	found, err := store.Find(upload.ID)
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	other, err := store.Create("laptop", ".png", 0)
+	require.NoError(t, err)
+	keys, err := blobs.List("")
+	require.NoError(t, err)
+	require.Equal(t, []string{uploadKey(other.ID)}, keys)
+}